@@ -0,0 +1,131 @@
+package decimal_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	gv "github.com/govalues/decimal"
+)
+
+// raceEnabled is set by race_test.go.
+var raceEnabled bool
+
+// skipAllocs skips allocation tests where intermediate big integers of
+// govalues escape to the heap: on 32-bit platforms and with the race detector.
+func skipAllocs(t *testing.T) {
+	t.Helper()
+	if math.MaxInt == math.MaxInt32 {
+		t.Skip("skipping allocation test on 32-bit platform")
+	}
+	if raceEnabled {
+		t.Skip("skipping allocation test with race detector")
+	}
+}
+
+// TestAllocs_Zero checks that arithmetic on govalues decimals does not
+// allocate, so that a regression fails "go test" and not only shows up
+// in -benchmem output.
+func TestAllocs_Zero(t *testing.T) {
+	skipAllocs(t)
+	for name, tt := range binarySizes {
+		x := gv.MustNew(tt.xcoef, int(tt.xscale))
+		y := gv.MustNew(tt.ycoef, int(tt.yscale))
+		for _, op := range []struct {
+			name string
+			fn   func()
+		}{
+			{"Add", func() { resultGV, resultError = x.Add(y) }},
+			{"Sub", func() { resultGV, resultError = x.Sub(y) }},
+			{"Mul", func() { resultGV, resultError = x.Mul(y) }},
+			{"QuoRem", func() { resultGV, resultGV, resultError = x.QuoRem(y) }},
+			{"Round", func() { resultGV = x.Round(2) }},
+			{"Trunc", func() { resultGV = x.Trunc(2) }},
+			{"Cmp", func() { resultInt = x.Cmp(y) }},
+		} {
+			if got := testing.AllocsPerRun(100, op.fn); got != 0 {
+				t.Errorf("%v/%v allocates %v times, want 0", op.name, name, got)
+			}
+		}
+	}
+
+	// Quotients that are exact within 19 digits.
+	// Other quotients are rounded with big integers, see TestAllocs_Budget.
+	for _, tt := range []binaryCase{
+		{10, 0, 4, 0},
+		{1, 0, 8, 0},
+		{1234567, 2, 2, 0},
+		{1234567, 2, 100, 0},
+		{1234567890123456780, 9, 2, 0},
+	} {
+		x := gv.MustNew(tt.xcoef, int(tt.xscale))
+		y := gv.MustNew(tt.ycoef, int(tt.yscale))
+		if got := testing.AllocsPerRun(100, func() { resultGV, resultError = x.Quo(y) }); got != 0 {
+			t.Errorf("Quo(%v, %v) allocates %v times, want 0", x, y, got)
+		}
+	}
+
+	for _, s := range []string{"0", "1", "-5", "0.01", "12345.67", "-123456789.1234567890"} {
+		if got := testing.AllocsPerRun(100, func() { resultGV, resultError = gv.Parse(s) }); got != 0 {
+			t.Errorf("Parse(%q) allocates %v times, want 0", s, got)
+		}
+	}
+}
+
+// TestAllocs_Budget checks the number of allocations of operations that
+// allocate by design.
+// The budgets are the maximum over the inputs of the corresponding
+// benchmarks, as measured with govalues v0.1.35, rounded up to leave room
+// for changes in math/big:
+//
+//   - String: 1, the returned string, except for single digits.
+//   - fmt.Appendf: 2, the interface conversion and the formatting buffer.
+//     There is no Append method, so this is the only way to append
+//     a decimal to a pre-sized buffer.
+//   - Quo: 2, if the quotient is inexact and rounded with big integers.
+//   - Exp: 100 (84 measured), Log: 600 (554 measured), Pow: 700 (646 measured),
+//     since they use big integers for intermediate results.
+func TestAllocs_Budget(t *testing.T) {
+	skipAllocs(t)
+
+	resultBytes = make([]byte, 0, 64)
+	for _, s := range stringTests {
+		x := gv.MustParse(s)
+		if got := testing.AllocsPerRun(100, func() { resultString = x.String() }); got > 1 {
+			t.Errorf("String/%v allocates %v times, want at most 1", s, got)
+		}
+		if got := testing.AllocsPerRun(100, func() { resultBytes = fmt.Appendf(resultBytes[:0], "%v", x) }); got > 2 {
+			t.Errorf("fmt.Appendf/%v allocates %v times, want at most 2", s, got)
+		}
+	}
+
+	for name, tt := range quoTests {
+		x := gv.MustNew(tt.xcoef, int(tt.xscale))
+		y := gv.MustNew(tt.ycoef, int(tt.yscale))
+		if got := testing.AllocsPerRun(100, func() { resultGV, resultError = x.Quo(y) }); got > 2 {
+			t.Errorf("Quo/%v allocates %v times, want at most 2", name, got)
+		}
+	}
+
+	for name, tt := range expTests {
+		x := gv.MustNew(tt.coef, int(tt.scale))
+		if got := testing.AllocsPerRun(10, func() { resultGV, resultError = x.Exp() }); got > 100 {
+			t.Errorf("Exp/%v allocates %v times, want at most 100", name, got)
+		}
+	}
+
+	for name, tt := range logTests {
+		x := gv.MustNew(tt.coef, int(tt.scale))
+		if got := testing.AllocsPerRun(10, func() { resultGV, resultError = x.Log() }); got > 600 {
+			t.Errorf("Log/%v allocates %v times, want at most 600", name, got)
+		}
+	}
+
+	for name, tt := range powTests {
+		x := gv.MustNew(tt.xcoef, int(tt.xscale))
+		y := gv.MustNew(tt.ycoef, int(tt.yscale))
+		if got := testing.AllocsPerRun(10, func() { resultGV, resultError = x.Pow(y) }); got > 700 {
+			t.Errorf("Pow/%v allocates %v times, want at most 700", name, got)
+		}
+	}
+}
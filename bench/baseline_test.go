@@ -0,0 +1,383 @@
+package decimal_test
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"math"
+	"math/bits"
+	"strconv"
+	"testing"
+
+	gv "github.com/govalues/decimal"
+)
+
+// Baselines without a decimal library.
+//
+//   - int64: hand-rolled fixed-point arithmetic, where a value is a scaled
+//     integer of type fixed. Every operation checks for overflow and
+//     rounds explicitly to the larger scale of its operands.
+//     Cases that do not fit 64 bits, such as size=mixed and the product
+//     of size=full, are skipped, so that the error path is not timed.
+//   - float64: binary floating-point arithmetic, where every result is
+//     rounded to cents with roundCents.
+
+// fixed is a decimal stored as a scaled integer: coef × 10^-scale.
+type fixed struct {
+	coef  int64
+	scale int32
+}
+
+var (
+	errFixedOverflow = errors.New("fixed-point overflow")
+	errFixedDivision = errors.New("fixed-point division by zero")
+	errFixedSyntax   = errors.New("fixed-point syntax error")
+)
+
+var fixedPow10 = [...]uint64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19,
+}
+
+func fixedAbs(x int64) uint64 {
+	if x < 0 {
+		return -uint64(x)
+	}
+	return uint64(x)
+}
+
+// fixedSign returns the signed coefficient, if it fits int64.
+func fixedSign(u uint64, neg bool) (int64, error) {
+	if u > math.MaxInt64 {
+		return 0, errFixedOverflow
+	}
+	if neg {
+		return -int64(u), nil
+	}
+	return int64(u), nil
+}
+
+// fixedRescale returns the coefficient of x at a larger or equal scale.
+func fixedRescale(x fixed, scale int32) (int64, error) {
+	hi, lo := bits.Mul64(fixedAbs(x.coef), fixedPow10[scale-x.scale])
+	if hi != 0 {
+		return 0, errFixedOverflow
+	}
+	return fixedSign(lo, x.coef < 0)
+}
+
+// fixedQuoRound divides the 128-bit number hi:lo by d and rounds the quotient
+// half to even.
+func fixedQuoRound(hi, lo, d uint64, neg bool) (int64, error) {
+	if hi >= d {
+		return 0, errFixedOverflow
+	}
+	q, r := bits.Div64(hi, lo, d)
+	if r > d-r || (r == d-r && q&1 == 1) {
+		q++
+	}
+	return fixedSign(q, neg)
+}
+
+func fixedAdd(x, y fixed) (fixed, error) {
+	scale := max(x.scale, y.scale)
+	a, err := fixedRescale(x, scale)
+	if err != nil {
+		return fixed{}, err
+	}
+	b, err := fixedRescale(y, scale)
+	if err != nil {
+		return fixed{}, err
+	}
+	c := a + b
+	if (b > 0 && c < a) || (b < 0 && c > a) {
+		return fixed{}, errFixedOverflow
+	}
+	return fixed{c, scale}, nil
+}
+
+func fixedMul(x, y fixed) (fixed, error) {
+	hi, lo := bits.Mul64(fixedAbs(x.coef), fixedAbs(y.coef))
+	c, err := fixedQuoRound(hi, lo, fixedPow10[min(x.scale, y.scale)], (x.coef < 0) != (y.coef < 0))
+	if err != nil {
+		return fixed{}, err
+	}
+	return fixed{c, max(x.scale, y.scale)}, nil
+}
+
+func fixedQuo(x, y fixed) (fixed, error) {
+	if y.coef == 0 {
+		return fixed{}, errFixedDivision
+	}
+	scale := max(x.scale, y.scale)
+	shift := scale - x.scale + y.scale
+	if int(shift) >= len(fixedPow10) {
+		return fixed{}, errFixedOverflow
+	}
+	hi, lo := bits.Mul64(fixedAbs(x.coef), fixedPow10[shift])
+	c, err := fixedQuoRound(hi, lo, fixedAbs(y.coef), (x.coef < 0) != (y.coef < 0))
+	if err != nil {
+		return fixed{}, err
+	}
+	return fixed{c, scale}, nil
+}
+
+// skipFixed skips the benchmark if op(x, y) fails, for example,
+// if the result does not fit 64 bits.
+func skipFixed(b *testing.B, op func(x, y fixed) (fixed, error), x, y fixed) {
+	b.Helper()
+	if _, err := op(x, y); err != nil {
+		b.Skipf("skipping operands %v and %v: %v", fixedString(x), fixedString(y), err)
+	}
+}
+
+// fixedParse parses a decimal in plain notation, such as "-123.45".
+func fixedParse(s string) (fixed, error) {
+	var (
+		u      uint64
+		scale  int32
+		neg    bool
+		point  bool
+		digits bool
+	)
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	for i := range len(s) {
+		switch c := s[i]; {
+		case c == '.' && !point:
+			point = true
+		case c >= '0' && c <= '9':
+			if u > (math.MaxInt64-uint64(c-'0'))/10 {
+				return fixed{}, errFixedOverflow
+			}
+			u = u*10 + uint64(c-'0')
+			digits = true
+			if point {
+				scale++
+			}
+		default:
+			return fixed{}, errFixedSyntax
+		}
+	}
+	if !digits || int(scale) >= len(fixedPow10) {
+		return fixed{}, errFixedSyntax
+	}
+	c, err := fixedSign(u, neg)
+	if err != nil {
+		return fixed{}, err
+	}
+	return fixed{c, scale}, nil
+}
+
+// fixedString formats x in plain notation with exactly x.scale digits
+// after the decimal point.
+func fixedString(x fixed) string {
+	var buf [24]byte
+	i := len(buf)
+	u := fixedAbs(x.coef)
+	for range x.scale {
+		i--
+		buf[i] = byte('0' + u%10)
+		u /= 10
+	}
+	if x.scale > 0 {
+		i--
+		buf[i] = '.'
+	}
+	for {
+		i--
+		buf[i] = byte('0' + u%10)
+		u /= 10
+		if u == 0 {
+			break
+		}
+	}
+	if x.coef < 0 {
+		i--
+		buf[i] = '-'
+	}
+	return string(buf[i:])
+}
+
+// roundCents rounds x to cents, half away from zero.
+func roundCents(x float64) float64 {
+	return math.Round(x*100) / 100
+}
+
+// telcoInt64 implements the Telco workload with integer cents.
+// The rates are scaled integers, and every product is rounded or truncated
+// to cents by integer division.
+type telcoInt64 struct {
+	out              *bufio.Writer
+	sumT, sumB, sumD int64
+}
+
+const (
+	telcoBaseRate    = 13  // 0.0013
+	telcoDistRate    = 894 // 0.00894
+	telcoBaseTaxRate = 675 // 0.0675
+	telcoDistTaxRate = 341 // 0.0341
+)
+
+func newTelcoInt64(w io.Writer) *telcoInt64 {
+	return &telcoInt64{out: bufio.NewWriter(w)}
+}
+
+// fixedRoundHalfEven returns c / d rounded half to even.
+func fixedRoundHalfEven(c, d int64) int64 {
+	q, r := c/d, c%d
+	if r < 0 {
+		r = -r
+	}
+	if 2*r > d || (2*r == d && q&1 == 1) {
+		if c < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return q
+}
+
+func (t *telcoInt64) call(rec telcoRecord) error {
+	n, err := rec.duration()
+	if err != nil {
+		return err
+	}
+	callType := n & 0x01
+
+	// Price
+	var price int64
+	if callType == 0 {
+		price = fixedRoundHalfEven(n*telcoBaseRate, 100)
+	} else {
+		price = fixedRoundHalfEven(n*telcoDistRate, 1000)
+	}
+
+	// Base Tax
+	baseTax := price * telcoBaseTaxRate / 10000
+	t.sumB += baseTax
+	finalPrice := price + baseTax
+
+	// Distance Tax
+	if callType != 0 {
+		distTax := price * telcoDistTaxRate / 10000
+		t.sumD += distTax
+		finalPrice += distTax
+	}
+
+	// Final Price
+	t.sumT += finalPrice
+	t.out.WriteString(fixedString(fixed{finalPrice, 2}))
+	return t.out.WriteByte('\n')
+}
+
+func (t *telcoInt64) sums() ([3]string, error) {
+	return [3]string{
+		fixedString(fixed{t.sumT, 2}),
+		fixedString(fixed{t.sumB, 2}),
+		fixedString(fixed{t.sumD, 2}),
+	}, t.out.Flush()
+}
+
+// telcoFloat64 implements the Telco workload with binary floating-point
+// numbers.
+// Prices and taxes are rounded to cents with roundCents, which neither rounds
+// half to even nor truncates, and the sums accumulate binary rounding errors,
+// so the totals drift from the decimal ones.
+type telcoFloat64 struct {
+	out              *bufio.Writer
+	buf              []byte
+	sumT, sumB, sumD float64
+}
+
+func newTelcoFloat64(w io.Writer) *telcoFloat64 {
+	return &telcoFloat64{out: bufio.NewWriter(w)}
+}
+
+func (t *telcoFloat64) call(rec telcoRecord) error {
+	n, err := rec.duration()
+	if err != nil {
+		return err
+	}
+	callType := n & 0x01
+
+	// Price
+	var price float64
+	if callType == 0 {
+		price = roundCents(float64(n) * 0.0013)
+	} else {
+		price = roundCents(float64(n) * 0.00894)
+	}
+
+	// Base Tax
+	baseTax := roundCents(price * 0.0675)
+	t.sumB += baseTax
+	finalPrice := price + baseTax
+
+	// Distance Tax
+	if callType != 0 {
+		distTax := roundCents(price * 0.0341)
+		t.sumD += distTax
+		finalPrice += distTax
+	}
+
+	// Final Price
+	finalPrice = roundCents(finalPrice)
+	t.sumT += finalPrice
+	t.buf = strconv.AppendFloat(t.buf[:0], finalPrice, 'f', 2, 64)
+	t.buf = append(t.buf, '\n')
+	_, err = t.out.Write(t.buf)
+	return err
+}
+
+func (t *telcoFloat64) sums() ([3]string, error) {
+	return [3]string{
+		strconv.FormatFloat(t.sumT, 'f', 2, 64),
+		strconv.FormatFloat(t.sumB, 'f', 2, 64),
+		strconv.FormatFloat(t.sumD, 'f', 2, 64),
+	}, t.out.Flush()
+}
+
+// telcoDrift processes every record with govalues and float64 and returns
+// the absolute differences between their totals.
+func telcoDrift(tests []telcoRecord) ([3]float64, error) {
+	var drift [3]float64
+	want, err := telcoRun(newTelcoGV(io.Discard), tests)
+	if err != nil {
+		return drift, err
+	}
+	got, err := telcoRun(newTelcoFloat64(io.Discard), tests)
+	if err != nil {
+		return drift, err
+	}
+	for i := range drift {
+		w, err := gv.Parse(want[i])
+		if err != nil {
+			return drift, err
+		}
+		g, err := gv.Parse(got[i])
+		if err != nil {
+			return drift, err
+		}
+		d, err := g.SubAbs(w)
+		if err != nil {
+			return drift, err
+		}
+		drift[i], _ = d.Float64()
+	}
+	return drift, nil
+}
+
+// telcoRun processes every record once and returns the totals.
+func telcoRun(t telco, tests []telcoRecord) ([3]string, error) {
+	for _, rec := range tests {
+		err := t.call(rec)
+		if err != nil {
+			return [3]string{}, err
+		}
+	}
+	return t.sums()
+}
@@ -1,9 +1,10 @@
 package decimal_test
 
 import (
-	"encoding/binary"
-	"io"
-	"os"
+	"database/sql/driver"
+	"encoding/json"
+	"math"
+	"strconv"
 	"testing"
 
 	cd "github.com/cockroachdb/apd/v3"
@@ -14,19 +15,118 @@ import (
 var (
 	resultString string
 	resultError  error
+	resultBool   bool
+	resultInt    int
+	resultInt64  int64
+	resultFloat  float64
+	resultBytes  []byte
+	resultValue  driver.Value
 	resultGV     gv.Decimal
 	resultSS     ss.Decimal
 )
 
-func BenchmarkDecimal_Add(b *testing.B) {
-	tests := map[string]struct {
-		xcoef  int64
-		xscale int32
-		ycoef  int64
-		yscale int32
+type unaryCase struct {
+	coef  int64
+	scale int32
+}
+
+type binaryCase struct {
+	xcoef  int64
+	xscale int32
+	ycoef  int64
+	yscale int32
+}
+
+type ternaryCase struct {
+	xcoef  int64
+	xscale int32
+	ycoef  int64
+	yscale int32
+	zcoef  int64
+	zscale int32
+}
+
+// Input-size matrix shared by arithmetic and transcendental benchmarks.
+// Every operation is measured with:
+//
+//   - small: small integers
+//   - money: typical monetary amounts with 2 to 4 digits after the decimal point
+//   - full: 19-digit coefficients
+//   - maxscale: 19 digits after the decimal point
+//   - mixed: operands with mismatched scales (binary operations only)
+//
+// Operands of Quantize are chosen so that the result fits 19 digits.
+// Operands of Exp, Pow, and PowInt are chosen to have the same number
+// of digits, but lower magnitudes, so that results do not overflow.
+var (
+	binarySizes = map[string]binaryCase{
+		"size=small":    {5, 0, 6, 0},
+		"size=money":    {1234567, 2, 8901, 2},
+		"size=full":     {1234567890123456789, 9, 7654321098765432109, 9},
+		"size=maxscale": {1234567890123456789, 19, 7654321098765432109, 19},
+		"size=mixed":    {123456789, 0, 7654321098765432109, 19},
+	}
+	ternarySizes = map[string]ternaryCase{
+		"size=small":    {5, 0, 6, 0, 7, 0},
+		"size=money":    {1234567, 2, 8901, 2, 825, 4},
+		"size=full":     {1234567890123456789, 9, 7654321098765432109, 9, 1234567890123456789, 19},
+		"size=maxscale": {1234567890123456789, 19, 7654321098765432109, 19, 1234567890123456789, 19},
+		"size=mixed":    {123456789, 0, 7654321098765432109, 19, 1234567, 2},
+	}
+	unarySizes = map[string]unaryCase{
+		"size=small":    {5, 0},
+		"size=money":    {1234567, 2},
+		"size=full":     {1234567890123456789, 9},
+		"size=maxscale": {1234567890123456789, 19},
+	}
+	expSizes = map[string]unaryCase{
+		"size=small":    {5, 0},
+		"size=money":    {1234, 2},
+		"size=full":     {1234567890123456789, 17},
+		"size=maxscale": {1234567890123456789, 19},
+	}
+	quantizeSizes = map[string]binaryCase{
+		"size=small":    {5, 0, 6, 0},
+		"size=money":    {123456789, 4, 1, 2},
+		"size=full":     {1234567890123456789, 9, 1, 4},
+		"size=maxscale": {1234567890123456789, 19, 1, 10},
+		"size=mixed":    {7654321098765432109, 19, 123456789, 0},
+	}
+	powSizes = map[string]binaryCase{
+		"size=small":    {5, 0, 3, 0},
+		"size=money":    {10525, 4, 25, 1},
+		"size=full":     {1234567890123456789, 18, 1234567890123456789, 18},
+		"size=maxscale": {1234567890123456789, 19, 7654321098765432109, 19},
+		"size=mixed":    {12345, 0, 1234567890123456789, 19},
+	}
+	powIntSizes = map[string]struct {
+		coef  int64
+		scale int32
+		power int64
 	}{
-		"5+6": {5, 0, 6, 0},
+		"size=small":    {5, 0, 10},
+		"size=money":    {10525, 4, 12},
+		"size=full":     {1234567890123456789, 18, 10},
+		"size=maxscale": {1234567890123456789, 19, 10},
+	}
+)
+
+// withSizes returns a copy of the matrix extended with the specific cases.
+func withSizes[T any](sizes, cases map[string]T) map[string]T {
+	tests := make(map[string]T, len(sizes)+len(cases))
+	for name, tt := range sizes {
+		tests[name] = tt
+	}
+	for name, tt := range cases {
+		tests[name] = tt
 	}
+	return tests
+}
+
+func BenchmarkDecimal_Add(b *testing.B) {
+	tests := withSizes(binarySizes, map[string]binaryCase{
+		"5+6": {5, 0, 6, 0},
+	})
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
 			b.Run("mod=govalues", func(b *testing.B) {
@@ -55,20 +155,68 @@ func BenchmarkDecimal_Add(b *testing.B) {
 					resultSS = x.Add(y)
 				}
 			})
+
+			// Scaled integers with explicit rounding.
+			b.Run("mod=int64", func(b *testing.B) {
+				skipFixed(b, fixedAdd, fixed{tt.xcoef, tt.xscale}, fixed{tt.ycoef, tt.yscale})
+				for range b.N {
+					x := fixed{tt.xcoef, tt.xscale}
+					y := fixed{tt.ycoef, tt.yscale}
+					var z fixed
+					z, resultError = fixedAdd(x, y)
+					resultInt64 = z.coef
+				}
+			})
+
+			b.Run("mod=float64", func(b *testing.B) {
+				for range b.N {
+					x := float64(tt.xcoef) / math.Pow10(int(tt.xscale))
+					y := float64(tt.ycoef) / math.Pow10(int(tt.yscale))
+					resultFloat = roundCents(x + y)
+				}
+			})
 		})
 	}
 }
 
-func BenchmarkDecimal_Mul(b *testing.B) {
-	tests := map[string]struct {
-		xcoef  int64
-		xscale int32
-		ycoef  int64
-		yscale int32
-	}{
-		"2*3": {2, 0, 3, 0},
+func BenchmarkDecimal_Sub(b *testing.B) {
+	tests := binarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					resultGV, resultError = x.Sub(y)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Sub(z, x, y)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					resultSS = x.Sub(y)
+				}
+			})
+		})
 	}
+}
 
+func BenchmarkDecimal_Mul(b *testing.B) {
+	tests := withSizes(binarySizes, map[string]binaryCase{
+		"2*3": {2, 0, 3, 0},
+	})
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
 			b.Run("mod=govalues", func(b *testing.B) {
@@ -97,20 +245,79 @@ func BenchmarkDecimal_Mul(b *testing.B) {
 					resultSS = x.Mul(y)
 				}
 			})
+
+			// Scaled integers with explicit rounding.
+			b.Run("mod=int64", func(b *testing.B) {
+				skipFixed(b, fixedMul, fixed{tt.xcoef, tt.xscale}, fixed{tt.ycoef, tt.yscale})
+				for range b.N {
+					x := fixed{tt.xcoef, tt.xscale}
+					y := fixed{tt.ycoef, tt.yscale}
+					var z fixed
+					z, resultError = fixedMul(x, y)
+					resultInt64 = z.coef
+				}
+			})
+
+			b.Run("mod=float64", func(b *testing.B) {
+				for range b.N {
+					x := float64(tt.xcoef) / math.Pow10(int(tt.xscale))
+					y := float64(tt.ycoef) / math.Pow10(int(tt.yscale))
+					resultFloat = roundCents(x * y)
+				}
+			})
 		})
 	}
 }
 
-func BenchmarkDecimal_Quo(b *testing.B) {
-	tests := map[string]struct {
-		xcoef  int64
-		xscale int32
-		ycoef  int64
-		yscale int32
-	}{
-		"2÷4": {2, 0, 4, 0},
-		"2÷3": {2, 0, 3, 0},
+func BenchmarkDecimal_AddMul(b *testing.B) {
+	tests := ternarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					z := gv.MustNew(tt.zcoef, int(tt.zscale))
+					resultGV, resultError = x.AddMul(y, z)
+				}
+			})
+
+			// There is no fused multiply-add, the product is rounded before the addition.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(tt.zcoef, -tt.zscale)
+					f := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Mul(f, y, z)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Add(f, x, f)
+					}
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					z := ss.New(tt.zcoef, -tt.zscale)
+					resultSS = x.Add(y.Mul(z))
+				}
+			})
+		})
 	}
+}
+
+// quoTests are the inputs of BenchmarkDecimal_Quo and TestAllocs_Budget.
+var quoTests = withSizes(binarySizes, map[string]binaryCase{
+	"2÷4": {2, 0, 4, 0},
+	"2÷3": {2, 0, 3, 0},
+})
+
+func BenchmarkDecimal_Quo(b *testing.B) {
+	tests := quoTests
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
 			b.Run("mod=govalues", func(b *testing.B) {
@@ -140,21 +347,121 @@ func BenchmarkDecimal_Quo(b *testing.B) {
 					resultSS = x.Div(y)
 				}
 			})
+
+			// Scaled integers with explicit rounding.
+			b.Run("mod=int64", func(b *testing.B) {
+				skipFixed(b, fixedQuo, fixed{tt.xcoef, tt.xscale}, fixed{tt.ycoef, tt.yscale})
+				for range b.N {
+					x := fixed{tt.xcoef, tt.xscale}
+					y := fixed{tt.ycoef, tt.yscale}
+					var z fixed
+					z, resultError = fixedQuo(x, y)
+					resultInt64 = z.coef
+				}
+			})
+
+			b.Run("mod=float64", func(b *testing.B) {
+				for range b.N {
+					x := float64(tt.xcoef) / math.Pow10(int(tt.xscale))
+					y := float64(tt.ycoef) / math.Pow10(int(tt.yscale))
+					resultFloat = roundCents(x / y)
+				}
+			})
 		})
 	}
 }
 
-func BenchmarkDecimal_Pow(b *testing.B) {
-	tests := map[string]struct {
-		xcoef  int64
-		xscale int32
-		ycoef  int64
-		yscale int32
-	}{
-		"10.1^1.5":       {101, 1, 15, 1},
-		"10000.1^1.5":    {100001, 1, 15, 1},
-		"10000000.1^1.5": {1000000001, 1, 15, 1},
+func BenchmarkDecimal_QuoRem(b *testing.B) {
+	tests := binarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					resultGV, resultGV, resultError = x.QuoRem(y)
+				}
+			})
+
+			// The quotient and the remainder are computed separately.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					q := cd.New(0, 0)
+					r := cd.New(0, 0)
+					_, resultError = cd.BaseContext.QuoInteger(q, x, y)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Rem(r, x, y)
+					}
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					resultSS, resultSS = x.QuoRem(y, 0)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_AddQuo(b *testing.B) {
+	tests := ternarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					z := gv.MustNew(tt.zcoef, int(tt.zscale))
+					resultGV, resultError = x.AddQuo(y, z)
+				}
+			})
+
+			// The quotient is rounded before the addition.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(tt.zcoef, -tt.zscale)
+					f := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Quo(f, y, z)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Add(f, x, f)
+					}
+				}
+			})
+
+			// The quotient is rounded before the addition.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				ss.DivisionPrecision = 19
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					z := ss.New(tt.zcoef, -tt.zscale)
+					resultSS = x.Add(y.Div(z))
+				}
+			})
+		})
 	}
+}
+
+// powTests are the inputs of BenchmarkDecimal_Pow and TestAllocs_Budget.
+var powTests = withSizes(powSizes, map[string]binaryCase{
+	"10.1^1.5":       {101, 1, 15, 1},
+	"10000.1^1.5":    {100001, 1, 15, 1},
+	"10000000.1^1.5": {1000000001, 1, 15, 1},
+})
+
+func BenchmarkDecimal_Pow(b *testing.B) {
+	tests := powTests
 
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
@@ -189,7 +496,7 @@ func BenchmarkDecimal_Pow(b *testing.B) {
 }
 
 func BenchmarkDecimal_PowInt(b *testing.B) {
-	tests := map[string]struct {
+	tests := withSizes(powIntSizes, map[string]struct {
 		coef  int64
 		scale int32
 		power int64
@@ -197,7 +504,7 @@ func BenchmarkDecimal_PowInt(b *testing.B) {
 		"1.1^60":     {11, 1, 60},
 		"1.01^600":   {101, 2, 600},
 		"1.001^6000": {1001, 3, 6000},
-	}
+	})
 
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
@@ -231,14 +538,11 @@ func BenchmarkDecimal_PowInt(b *testing.B) {
 }
 
 func BenchmarkDecimal_Sqrt(b *testing.B) {
-	tests := map[string]struct {
-		coef  int64
-		scale int32
-	}{
+	tests := withSizes(unarySizes, map[string]unaryCase{
 		"0.0000000000000000002": {2, 19},
 		"2":                     {2, 0},
 		"2000000000000000000":   {2000000000000000000, 0},
-	}
+	})
 
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
@@ -262,16 +566,16 @@ func BenchmarkDecimal_Sqrt(b *testing.B) {
 	}
 }
 
+// expTests are the inputs of BenchmarkDecimal_Exp and TestAllocs_Budget.
+var expTests = withSizes(expSizes, map[string]unaryCase{
+	"-5":   {-5, 0},
+	"5":    {5, 0},
+	"-0.5": {-5, 1},
+	"0.5":  {5, 1},
+})
+
 func BenchmarkDecimal_Exp(b *testing.B) {
-	tests := map[string]struct {
-		coef  int64
-		scale int32
-	}{
-		"-5":   {-5, 0},
-		"5":    {5, 0},
-		"-0.5": {-5, 1},
-		"0.5":  {5, 1},
-	}
+	tests := expTests
 
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
@@ -302,16 +606,16 @@ func BenchmarkDecimal_Exp(b *testing.B) {
 	}
 }
 
+// logTests are the inputs of BenchmarkDecimal_Log and TestAllocs_Budget.
+var logTests = withSizes(unarySizes, map[string]unaryCase{
+	"0.000005": {5, 6},
+	"0.5":      {5, 1},
+	"500":      {500, 0},
+	"500000":   {500_000, 0},
+})
+
 func BenchmarkDecimal_Log(b *testing.B) {
-	tests := map[string]struct {
-		coef  int64
-		scale int32
-	}{
-		"0.000005": {5, 6},
-		"0.5":      {5, 1},
-		"500":      {500, 0},
-		"500000":   {500_000, 0},
-	}
+	tests := logTests
 
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
@@ -343,15 +647,12 @@ func BenchmarkDecimal_Log(b *testing.B) {
 }
 
 func BenchmarkDecimal_Log10(b *testing.B) {
-	tests := map[string]struct {
-		coef  int64
-		scale int32
-	}{
+	tests := withSizes(unarySizes, map[string]unaryCase{
 		"0.000005": {5, 6},
 		"0.5":      {5, 1},
 		"500":      {500, 0},
 		"500000":   {500_000, 0},
-	}
+	})
 	for name, tt := range tests {
 		b.Run(name, func(b *testing.B) {
 			b.Run("mod=govalues", func(b *testing.B) {
@@ -374,314 +675,948 @@ func BenchmarkDecimal_Log10(b *testing.B) {
 	}
 }
 
-func BenchmarkParse(b *testing.B) {
-	tests := []string{
-		"1",
-		"12345.12345",
-		"123456789.1234567890",
-	}
-	for _, s := range tests {
-		b.Run(s, func(b *testing.B) {
+func BenchmarkDecimal_Log2(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
 			b.Run("mod=govalues", func(b *testing.B) {
 				for range b.N {
-					resultGV, resultError = gv.Parse(s)
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultGV, resultError = x.Log2()
 				}
 			})
 
+			// There is no binary logarithm, it is computed as ln(x) / ln(2).
 			b.Run("mod=cockroachdb", func(b *testing.B) {
-				d := cd.New(0, 0)
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				ln2 := cd.New(0, 0)
+				_, err := cd.BaseContext.Ln(ln2, cd.New(2, 0))
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ResetTimer()
 				for range b.N {
-					_, _, resultError = d.SetString(s)
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Ln(z, x)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Quo(z, z, ln2)
+					}
 				}
 			})
 
+			// There is no binary logarithm, it is computed as ln(x) / ln(2).
 			b.Run("mod=shopspring", func(b *testing.B) {
+				ss.DivisionPrecision = 19
+				ln2, err := ss.New(2, 0).Ln(19)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ResetTimer()
 				for range b.N {
-					resultSS, resultError = ss.NewFromString(s)
+					x := ss.New(tt.coef, -tt.scale)
+					resultSS, resultError = x.Ln(19)
+					if resultError == nil {
+						resultSS = resultSS.Div(ln2)
+					}
 				}
 			})
 		})
 	}
 }
 
-func BenchmarkDecimal_String(b *testing.B) {
-	tests := []string{
-		"1",
-		"123.456",
-		"123456789.1234567890",
-	}
-
-	for _, s := range tests {
-		b.Run(s, func(b *testing.B) {
+func BenchmarkDecimal_Round(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
 			b.Run("mod=govalues", func(b *testing.B) {
-				d, err := gv.Parse(s)
-				if err != nil {
-					b.Fatal(err)
-				}
-				b.ResetTimer()
 				for range b.N {
-					resultString = d.String()
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultGV = x.Round(2)
 				}
 			})
 
+			// Rounding to a scale is a quantization with a rounding mode.
 			b.Run("mod=cockroachdb", func(b *testing.B) {
-				d := cd.New(0, 0)
-				d, _, err := d.SetString(s)
-				if err != nil {
-					b.Fatal(err)
-				}
-				b.ResetTimer()
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				ctx := cd.BaseContext
+				ctx.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					_, resultError = ctx.Quantize(z, x, -2)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					resultSS = x.RoundBank(2)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Trunc(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultGV = x.Trunc(2)
+				}
+			})
+
+			// Rounding to a scale is a quantization with a rounding mode.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				ctx := cd.BaseContext
+				ctx.Rounding = cd.RoundDown
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					_, resultError = ctx.Quantize(z, x, -2)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					resultSS = x.Truncate(2)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Ceil(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultGV = x.Ceil(2)
+				}
+			})
+
+			// Rounding to a scale is a quantization with a rounding mode.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				ctx := cd.BaseContext
+				ctx.Rounding = cd.RoundCeiling
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					_, resultError = ctx.Quantize(z, x, -2)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					resultSS = x.RoundCeil(2)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Floor(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultGV = x.Floor(2)
+				}
+			})
+
+			// Rounding to a scale is a quantization with a rounding mode.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				ctx := cd.BaseContext
+				ctx.Rounding = cd.RoundFloor
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					_, resultError = ctx.Quantize(z, x, -2)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					resultSS = x.RoundFloor(2)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Rescale(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultGV = x.Rescale(4)
+				}
+			})
+
+			// Rescaling is a quantization to a fixed exponent.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Quantize(z, x, -4)
+				}
+			})
+
+			// Rounding to a larger number of digits pads the decimal with zeros.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					resultSS = x.RoundBank(4)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Quantize(b *testing.B) {
+	tests := quantizeSizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					resultGV = x.Quantize(y)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Quantize(z, x, y.Exponent)
+				}
+			})
+
+			// There is no quantization, the decimal is rounded to the scale of y.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					resultSS = x.RoundBank(-y.Exponent())
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Cmp(b *testing.B) {
+	tests := binarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					resultInt = x.Cmp(y)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					resultInt = x.Cmp(y)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					resultInt = x.Cmp(y)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkSum(b *testing.B) {
+	tests := ternarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					z := gv.MustNew(tt.zcoef, int(tt.zscale))
+					resultGV, resultError = gv.Sum(x, y, z)
+				}
+			})
+
+			// There is no sum function, the decimals are added one by one.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(tt.zcoef, -tt.zscale)
+					f := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Add(f, x, y)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Add(f, f, z)
+					}
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					z := ss.New(tt.zcoef, -tt.zscale)
+					resultSS = ss.Sum(x, y, z)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkProd(b *testing.B) {
+	tests := ternarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					z := gv.MustNew(tt.zcoef, int(tt.zscale))
+					resultGV, resultError = gv.Prod(x, y, z)
+				}
+			})
+
+			// There is no product function, the decimals are multiplied one by one.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(tt.zcoef, -tt.zscale)
+					f := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Mul(f, x, y)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Mul(f, f, z)
+					}
+				}
+			})
+
+			// There is no product function, the decimals are multiplied one by one.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					z := ss.New(tt.zcoef, -tt.zscale)
+					resultSS = x.Mul(y).Mul(z)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkMean(b *testing.B) {
+	tests := ternarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.xcoef, int(tt.xscale))
+					y := gv.MustNew(tt.ycoef, int(tt.yscale))
+					z := gv.MustNew(tt.zcoef, int(tt.zscale))
+					resultGV, resultError = gv.Mean(x, y, z)
+				}
+			})
+
+			// There is no mean function, the sum is divided by the count.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				three := cd.New(3, 0)
+				for range b.N {
+					x := cd.New(tt.xcoef, -tt.xscale)
+					y := cd.New(tt.ycoef, -tt.yscale)
+					z := cd.New(tt.zcoef, -tt.zscale)
+					f := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Add(f, x, y)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Add(f, f, z)
+					}
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Quo(f, f, three)
+					}
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				ss.DivisionPrecision = 19
+				for range b.N {
+					x := ss.New(tt.xcoef, -tt.xscale)
+					y := ss.New(tt.ycoef, -tt.yscale)
+					z := ss.New(tt.zcoef, -tt.zscale)
+					resultSS = ss.Avg(x, y, z)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	tests := []string{
+		"1",
+		"12345.12345",
+		"123456789.1234567890",
+	}
+	for _, s := range tests {
+		b.Run(s, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					resultGV, resultError = gv.Parse(s)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				for range b.N {
+					_, _, resultError = d.SetString(s)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					resultSS, resultError = ss.NewFromString(s)
+				}
+			})
+
+			b.Run("mod=int64", func(b *testing.B) {
+				var d fixed
+				for range b.N {
+					d, resultError = fixedParse(s)
+				}
+				resultInt64 = d.coef
+			})
+
+			b.Run("mod=float64", func(b *testing.B) {
+				for range b.N {
+					resultFloat, resultError = strconv.ParseFloat(s, 64)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Float64(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultFloat, resultBool = x.Float64()
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					resultFloat, resultError = x.Float64()
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					resultFloat, resultBool = x.Float64()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkNewFromFloat64(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		f, _ := gv.MustNew(tt.coef, int(tt.scale)).Float64()
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
 				for range b.N {
-					resultString = d.Text('f')
+					resultGV, resultError = gv.NewFromFloat64(f)
 				}
 			})
 
-			b.Run("mod=shopspring", func(b *testing.B) {
-				d, err := ss.NewFromString(s)
-				if err != nil {
-					b.Fatal(err)
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				for range b.N {
+					z := cd.New(0, 0)
+					_, resultError = z.SetFloat64(f)
 				}
-				b.ResetTimer()
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
 				for range b.N {
-					resultString = d.String()
+					resultSS = ss.NewFromFloat(f)
 				}
 			})
 		})
 	}
 }
 
-// BenchmarkDecimal_Telco implements computational part of "[Telco benchmark]"
-// by Mike Cowlishaw.
-// I/O part is not implemented.
-//
-// [Telco benchmark]: https://speleotrove.com/decimal/telco.html
-func BenchmarkDecimal_Telco(b *testing.B) {
-	tests, err := readTelcoTests()
-	if err != nil {
-		b.Fatal(err)
+func BenchmarkDecimal_Int64(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					x := gv.MustNew(tt.coef, int(tt.scale))
+					resultInt64, resultInt64, resultBool = x.Int64(2)
+				}
+			})
+
+			// The whole and fractional parts are computed by integer division and remainder.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				cd.BaseContext.Precision = 19
+				cd.BaseContext.Rounding = cd.RoundHalfEven
+				one := cd.New(1, 0)
+				for range b.N {
+					x := cd.New(tt.coef, -tt.scale)
+					z := cd.New(0, 0)
+					whole := cd.New(0, 0)
+					frac := cd.New(0, 0)
+					_, resultError = cd.BaseContext.Quantize(z, x, -2)
+					if resultError == nil {
+						_, resultError = cd.BaseContext.QuoInteger(whole, z, one)
+					}
+					if resultError == nil {
+						_, resultError = cd.BaseContext.Rem(frac, z, one)
+					}
+					if resultError == nil {
+						resultInt64, resultError = whole.Int64()
+					}
+					if resultError == nil {
+						frac.Exponent = 0
+						resultInt64, resultError = frac.Int64()
+					}
+				}
+			})
+
+			// The whole and fractional parts are computed by truncation and subtraction.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					x := ss.New(tt.coef, -tt.scale)
+					x = x.RoundBank(2)
+					resultInt64 = x.IntPart()
+					resultInt64 = x.Sub(x.Truncate(0)).Shift(2).IntPart()
+				}
+			})
+		})
 	}
+}
 
-	b.Run("mod=govalues", func(b *testing.B) {
-		totalFinalPrice := gv.Zero
-		totalBaseTax := gv.Zero
-		totalDistTax := gv.Zero
-		baseRate := gv.MustParse("0.0013")
-		distRate := gv.MustParse("0.00894")
-		baseTaxRate := gv.MustParse("0.0675")
-		distTaxRate := gv.MustParse("0.0341")
-		b.ResetTimer()
-		for i := range b.N {
-			var err error
-			tt := tests[i%len(tests)]
-			callType := tt & 0x01
-
-			// Duration, Seconds
-			duration := gv.MustNew(tt, 0)
-
-			// Price
-			var price gv.Decimal
-			if callType == 0 {
-				price, err = duration.Mul(baseRate)
-			} else {
-				price, err = duration.Mul(distRate)
-			}
-			if err != nil {
-				b.Fatal(err)
-			}
-			price = price.Round(2)
-
-			// Base Tax
-			baseTax, err := price.Mul(baseTaxRate)
-			if err != nil {
-				b.Fatal(err)
-			}
-			baseTax = baseTax.Trunc(2)
-			totalBaseTax, err = totalBaseTax.Add(baseTax)
-			if err != nil {
-				b.Fatal(err)
-			}
-			finalPrice, err := price.Add(baseTax)
-			if err != nil {
-				b.Fatal(err)
-			}
-
-			// Distance Tax
-			if callType != 0 {
-				distTax, err := price.Mul(distTaxRate)
+// stringTests are the inputs of BenchmarkDecimal_String and TestAllocs_Budget.
+var stringTests = []string{
+	"1",
+	"123.456",
+	"123456789.1234567890",
+}
+
+func BenchmarkDecimal_String(b *testing.B) {
+	tests := stringTests
+
+	for _, s := range tests {
+		b.Run(s, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				d, err := gv.Parse(s)
 				if err != nil {
 					b.Fatal(err)
 				}
-				distTax = distTax.Trunc(2)
-				totalDistTax, err = totalDistTax.Add(distTax)
+				b.ResetTimer()
+				for range b.N {
+					resultString = d.String()
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				d, _, err := d.SetString(s)
 				if err != nil {
 					b.Fatal(err)
 				}
-				finalPrice, err = finalPrice.Add(distTax)
+				b.ResetTimer()
+				for range b.N {
+					resultString = d.Text('f')
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d, err := ss.NewFromString(s)
 				if err != nil {
 					b.Fatal(err)
 				}
-			}
+				b.ResetTimer()
+				for range b.N {
+					resultString = d.String()
+				}
+			})
 
-			// Final Price
-			totalFinalPrice, err = totalFinalPrice.Add(finalPrice)
-			if err != nil {
-				b.Fatal(err)
-			}
-			resultString = finalPrice.String()
-		}
-	})
+			b.Run("mod=int64", func(b *testing.B) {
+				d, err := fixedParse(s)
+				if err != nil {
+					b.Fatal(err)
+				}
+				b.ResetTimer()
+				for range b.N {
+					resultString = fixedString(d)
+				}
+			})
 
-	b.Run("mod=cockroachdb", func(b *testing.B) {
-		cd.BaseContext.Precision = 19
-		cd.BaseContext.Rounding = cd.RoundHalfEven
-		totalFinalPrice := cd.New(0, 0)
-		totalBaseTax := cd.New(0, 0)
-		totalDistTax := cd.New(0, 0)
-		baseRate := cd.New(13, -4)     // 0.0013
-		distRate := cd.New(894, -5)    // 0.00894
-		baseTaxRate := cd.New(675, -4) // 0.0675
-		distTaxRate := cd.New(341, -4) // 0.0341
-		b.ResetTimer()
-		for i := range b.N {
-			var err error
-			tt := tests[i%len(tests)]
-			callType := tt & 0x01
-
-			// Duration, Seconds
-			duration := cd.New(tt, 0)
-
-			// Price
-			price := new(cd.Decimal)
-			if callType == 0 {
-				_, err = cd.BaseContext.Mul(price, duration, baseRate)
-			} else {
-				_, err = cd.BaseContext.Mul(price, duration, distRate)
-			}
-			if err != nil {
-				b.Fatal(err)
-			}
-			cd.BaseContext.Rounding = cd.RoundHalfEven
-			_, err = cd.BaseContext.Quantize(price, price, -2)
-			if err != nil {
-				b.Fatal(err)
-			}
-
-			// Base Tax
-			baseTax := new(cd.Decimal)
-			_, err = cd.BaseContext.Mul(baseTax, price, baseTaxRate)
-			if err != nil {
-				b.Fatal(err)
-			}
-			cd.BaseContext.Rounding = cd.RoundDown
-			_, err = cd.BaseContext.Quantize(baseTax, baseTax, -2)
-			if err != nil {
-				b.Fatal(err)
-			}
-			_, err = cd.BaseContext.Add(totalBaseTax, totalBaseTax, baseTax)
-			if err != nil {
-				b.Fatal(err)
-			}
-			finalPrice := new(cd.Decimal)
-			_, err = cd.BaseContext.Add(finalPrice, price, baseTax)
-			if err != nil {
-				b.Fatal(err)
-			}
-
-			// Distance Tax
-			if callType != 0 {
-				distTax := new(cd.Decimal)
-				_, err = cd.BaseContext.Mul(distTax, price, distTaxRate)
+			b.Run("mod=float64", func(b *testing.B) {
+				d, err := strconv.ParseFloat(s, 64)
 				if err != nil {
 					b.Fatal(err)
 				}
-				cd.BaseContext.Rounding = cd.RoundDown
-				_, err = cd.BaseContext.Quantize(distTax, distTax, -2)
+				b.ResetTimer()
+				for range b.N {
+					resultString = strconv.FormatFloat(d, 'f', -1, 64)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_MarshalText(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := gv.MustNew(tt.coef, int(tt.scale))
+				for range b.N {
+					resultBytes, resultError = d.MarshalText()
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultBytes, resultError = d.MarshalText()
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := ss.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultBytes, resultError = d.MarshalText()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_UnmarshalText(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		data, err := gv.MustNew(tt.coef, int(tt.scale)).MarshalText()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				var d gv.Decimal
+				for range b.N {
+					resultError = d.UnmarshalText(data)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				for range b.N {
+					resultError = d.UnmarshalText(data)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				var d ss.Decimal
+				for range b.N {
+					resultError = d.UnmarshalText(data)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_MarshalBinary(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := gv.MustNew(tt.coef, int(tt.scale))
+				for range b.N {
+					resultBytes, resultError = d.MarshalBinary()
+				}
+			})
+
+			// There is no binary encoding, the text encoding is used.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultBytes, resultError = d.MarshalText()
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := ss.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultBytes, resultError = d.MarshalBinary()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_UnmarshalBinary(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				data, err := gv.MustNew(tt.coef, int(tt.scale)).MarshalBinary()
 				if err != nil {
 					b.Fatal(err)
 				}
-				_, err = cd.BaseContext.Add(totalDistTax, totalDistTax, distTax)
+				var d gv.Decimal
+				b.ResetTimer()
+				for range b.N {
+					resultError = d.UnmarshalBinary(data)
+				}
+			})
+
+			// There is no binary encoding, the text encoding is used.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				data, err := cd.New(tt.coef, -tt.scale).MarshalText()
 				if err != nil {
 					b.Fatal(err)
 				}
-				_, err = cd.BaseContext.Add(finalPrice, finalPrice, distTax)
+				d := cd.New(0, 0)
+				b.ResetTimer()
+				for range b.N {
+					resultError = d.UnmarshalText(data)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				data, err := ss.New(tt.coef, -tt.scale).MarshalBinary()
 				if err != nil {
 					b.Fatal(err)
 				}
-			}
+				var d ss.Decimal
+				b.ResetTimer()
+				for range b.N {
+					resultError = d.UnmarshalBinary(data)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Value(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := gv.MustNew(tt.coef, int(tt.scale))
+				for range b.N {
+					resultValue, resultError = d.Value()
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultValue, resultError = d.Value()
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := ss.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultValue, resultError = d.Value()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_Scan(b *testing.B) {
+	tests := map[string]any{
+		"src=string":  "12345.67",
+		"src=bytes":   []byte("12345.67"),
+		"src=int64":   int64(1234567),
+		"src=float64": 12345.67,
+	}
+	for name, src := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				var d gv.Decimal
+				for range b.N {
+					resultError = d.Scan(src)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				for range b.N {
+					resultError = d.Scan(src)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				var d ss.Decimal
+				for range b.N {
+					resultError = d.Scan(src)
+				}
+			})
+		})
+	}
+}
+
+// invoiceLine is a typical document with 16 decimal fields out of 20.
+type invoiceLine[T any] struct {
+	ID             int64  `json:"id"`
+	SKU            string `json:"sku"`
+	Description    string `json:"description"`
+	Currency       string `json:"currency"`
+	Quantity       T      `json:"quantity"`
+	UnitPrice      T      `json:"unit_price"`
+	ListPrice      T      `json:"list_price"`
+	DiscountRate   T      `json:"discount_rate"`
+	DiscountAmount T      `json:"discount_amount"`
+	NetAmount      T      `json:"net_amount"`
+	TaxRate        T      `json:"tax_rate"`
+	TaxAmount      T      `json:"tax_amount"`
+	DutyRate       T      `json:"duty_rate"`
+	DutyAmount     T      `json:"duty_amount"`
+	ShippingAmount T      `json:"shipping_amount"`
+	GrossAmount    T      `json:"gross_amount"`
+	ExchangeRate   T      `json:"exchange_rate"`
+	BaseAmount     T      `json:"base_amount"`
+	Weight         T      `json:"weight"`
+	Volume         T      `json:"volume"`
+}
+
+// newInvoiceLine returns an invoice line with decimals created by fn.
+func newInvoiceLine[T any](fn func(coef int64, scale int32) T) invoiceLine[T] {
+	return invoiceLine[T]{
+		ID:             1234567,
+		SKU:            "SKU-000123",
+		Description:    "Widget, large",
+		Currency:       "EUR",
+		Quantity:       fn(12, 0),
+		UnitPrice:      fn(1999, 2),
+		ListPrice:      fn(2499, 2),
+		DiscountRate:   fn(2, 1),
+		DiscountAmount: fn(5998, 2),
+		NetAmount:      fn(23988, 2),
+		TaxRate:        fn(21, 2),
+		TaxAmount:      fn(503748, 4),
+		DutyRate:       fn(35, 3),
+		DutyAmount:     fn(8396, 3),
+		ShippingAmount: fn(1250, 2),
+		GrossAmount:    fn(3058652, 4),
+		ExchangeRate:   fn(10852, 4),
+		BaseAmount:     fn(331924916, 6),
+		Weight:         fn(14400, 3),
+		Volume:         fn(36, 3),
+	}
+}
 
-			// Final Price
-			_, err = cd.BaseContext.Add(totalFinalPrice, totalFinalPrice, finalPrice)
-			if err != nil {
-				b.Fatal(err)
-			}
-			resultString = finalPrice.String()
+func BenchmarkInvoiceLine_MarshalJSON(b *testing.B) {
+	b.Run("mod=govalues", func(b *testing.B) {
+		line := newInvoiceLine(func(coef int64, scale int32) gv.Decimal {
+			return gv.MustNew(coef, int(scale))
+		})
+		b.ResetTimer()
+		for range b.N {
+			resultBytes, resultError = json.Marshal(line)
+		}
+	})
+
+	b.Run("mod=cockroachdb", func(b *testing.B) {
+		line := newInvoiceLine(func(coef int64, scale int32) *cd.Decimal {
+			return cd.New(coef, -scale)
+		})
+		b.ResetTimer()
+		for range b.N {
+			resultBytes, resultError = json.Marshal(line)
 		}
 	})
 
 	b.Run("mod=shopspring", func(b *testing.B) {
-		totalFinalPrice := ss.Zero
-		totalBaseTax := ss.Zero
-		totalDistTax := ss.Zero
-		baseRate := ss.RequireFromString("0.0013")
-		distRate := ss.RequireFromString("0.00894")
-		baseTaxRate := ss.RequireFromString("0.0675")
-		distTaxRate := ss.RequireFromString("0.0341")
+		line := newInvoiceLine(func(coef int64, scale int32) ss.Decimal {
+			return ss.New(coef, -scale)
+		})
 		b.ResetTimer()
-		for i := range b.N {
-			tt := tests[i%len(tests)]
-			callType := tt & 0x01
-
-			// Duration, Seconds
-			duration := ss.NewFromInt(tt)
-
-			// Price
-			var price ss.Decimal
-			if callType == 0 {
-				price = duration.Mul(baseRate)
-			} else {
-				price = duration.Mul(distRate)
-			}
-			price = price.RoundBank(2)
-
-			// Base Tax
-			baseTax := price.Mul(baseTaxRate)
-			baseTax = baseTax.RoundDown(2)
-			totalBaseTax = totalBaseTax.Add(baseTax)
-			finalPrice := price.Add(baseTax)
-
-			// Distance Tax
-			if callType != 0 {
-				distTax := price.Mul(distTaxRate)
-				distTax = distTax.RoundDown(2)
-				totalDistTax = totalDistTax.Add(distTax)
-				finalPrice = finalPrice.Add(distTax)
-			}
-
-			// Final Price
-			totalFinalPrice = totalFinalPrice.Add(finalPrice)
-			resultString = finalPrice.String()
+		for range b.N {
+			resultBytes, resultError = json.Marshal(line)
 		}
 	})
 }
 
-func readTelcoTests() ([]int64, error) {
-	file, err := os.Open("expon180.1e6b")
+func BenchmarkInvoiceLine_UnmarshalJSON(b *testing.B) {
+	data, err := json.Marshal(newInvoiceLine(func(coef int64, scale int32) gv.Decimal {
+		return gv.MustNew(coef, int(scale))
+	}))
 	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-	data := make([]int64, 0, 1000000)
-	buf := make([]byte, 8)
-	for {
-		_, err := file.Read(buf)
-		if err == io.EOF {
-			break
+		b.Fatal(err)
+	}
+
+	b.Run("mod=govalues", func(b *testing.B) {
+		for range b.N {
+			var line invoiceLine[gv.Decimal]
+			resultError = json.Unmarshal(data, &line)
 		}
-		if err != nil {
-			return nil, err
+	})
+
+	b.Run("mod=cockroachdb", func(b *testing.B) {
+		for range b.N {
+			var line invoiceLine[*cd.Decimal]
+			resultError = json.Unmarshal(data, &line)
 		}
-		num := binary.BigEndian.Uint64(buf)
-		data = append(data, int64(num))
-	}
-	return data, nil
+	})
+
+	b.Run("mod=shopspring", func(b *testing.B) {
+		for range b.N {
+			var line invoiceLine[ss.Decimal]
+			resultError = json.Unmarshal(data, &line)
+		}
+	})
 }
@@ -0,0 +1,162 @@
+//go:build !benchtrend
+
+package decimal_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+// JSON and BSON encodings were added in govalues/decimal v0.1.35.
+// Their benchmarks are left out of the benchtrend tool, which builds
+// with the benchtrend tag, so that older versions can be compared.
+
+func BenchmarkDecimal_MarshalJSON(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := gv.MustNew(tt.coef, int(tt.scale))
+				for range b.N {
+					resultBytes, resultError = d.MarshalJSON()
+				}
+			})
+
+			// There is no JSON encoding, json.Marshal uses the text encoding.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultBytes, resultError = json.Marshal(d)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := ss.New(tt.coef, -tt.scale)
+				for range b.N {
+					resultBytes, resultError = d.MarshalJSON()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_UnmarshalJSON(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		data, err := gv.MustNew(tt.coef, int(tt.scale)).MarshalJSON()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				var d gv.Decimal
+				for range b.N {
+					resultError = d.UnmarshalJSON(data)
+				}
+			})
+
+			// There is no JSON encoding, json.Unmarshal uses the text encoding.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				for range b.N {
+					resultError = json.Unmarshal(data, d)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				var d ss.Decimal
+				for range b.N {
+					resultError = d.UnmarshalJSON(data)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_MarshalBSONValue(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := gv.MustNew(tt.coef, int(tt.scale))
+				for range b.N {
+					_, resultBytes, resultError = d.MarshalBSONValue()
+				}
+			})
+
+			// There is no BSON encoding, the decimal is converted to Decimal128
+			// through its string representation.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(tt.coef, -tt.scale)
+				for range b.N {
+					var e bson.Decimal128
+					e, resultError = bson.ParseDecimal128(d.String())
+					if resultError == nil {
+						_, resultBytes, resultError = bson.MarshalValue(e)
+					}
+				}
+			})
+
+			// There is no BSON encoding, the decimal is converted to Decimal128
+			// through its string representation.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := ss.New(tt.coef, -tt.scale)
+				for range b.N {
+					var e bson.Decimal128
+					e, resultError = bson.ParseDecimal128(d.String())
+					if resultError == nil {
+						_, resultBytes, resultError = bson.MarshalValue(e)
+					}
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_UnmarshalBSONValue(b *testing.B) {
+	tests := unarySizes
+	for name, tt := range tests {
+		typ, data, err := gv.MustNew(tt.coef, int(tt.scale)).MarshalBSONValue()
+		if err != nil {
+			b.Fatal(err)
+		}
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				var d gv.Decimal
+				for range b.N {
+					resultError = d.UnmarshalBSONValue(typ, data)
+				}
+			})
+
+			// There is no BSON encoding, the decimal is converted from Decimal128
+			// through its string representation.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				for range b.N {
+					var e bson.Decimal128
+					resultError = bson.UnmarshalValue(bson.Type(typ), data, &e)
+					if resultError == nil {
+						_, _, resultError = d.SetString(e.String())
+					}
+				}
+			})
+
+			// There is no BSON encoding, the decimal is converted from Decimal128
+			// through its string representation.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					var e bson.Decimal128
+					resultError = bson.UnmarshalValue(bson.Type(typ), data, &e)
+					if resultError == nil {
+						resultSS, resultError = ss.NewFromString(e.String())
+					}
+				}
+			})
+		})
+	}
+}
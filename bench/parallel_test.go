@@ -0,0 +1,244 @@
+package decimal_test
+
+import (
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+)
+
+// resultMu guards the results of parallel benchmarks.
+var resultMu sync.Mutex
+
+// parallelSizes are the typical and the worst cases of binarySizes.
+var parallelSizes = map[string]binaryCase{
+	"size=money": binarySizes["size=money"],
+	"size=full":  binarySizes["size=full"],
+}
+
+// runParallel runs body with b.RunParallel at GOMAXPROCS 1, 2, 4,
+// and the number of CPUs, and reports the throughput in operations per second.
+func runParallel(b *testing.B, body func(pb *testing.PB)) {
+	procs := []int{1, 2, 4}
+	if n := runtime.NumCPU(); !slices.Contains(procs, n) {
+		procs = append(procs, n)
+	}
+	for _, p := range procs {
+		b.Run(fmt.Sprintf("procs=%v", p), func(b *testing.B) {
+			defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(p))
+			b.ReportAllocs()
+			b.RunParallel(body)
+			b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "ops/s")
+		})
+	}
+}
+
+func BenchmarkDecimal_AddParallel(b *testing.B) {
+	tests := parallelSizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					var z gv.Decimal
+					var err error
+					for pb.Next() {
+						x := gv.MustNew(tt.xcoef, int(tt.xscale))
+						y := gv.MustNew(tt.ycoef, int(tt.yscale))
+						z, err = x.Add(y)
+					}
+					resultMu.Lock()
+					resultGV, resultError = z, err
+					resultMu.Unlock()
+				})
+			})
+
+			// Every goroutine has its own context.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					ctx := cd.BaseContext
+					ctx.Precision = 19
+					ctx.Rounding = cd.RoundHalfEven
+					var err error
+					for pb.Next() {
+						x := cd.New(tt.xcoef, -tt.xscale)
+						y := cd.New(tt.ycoef, -tt.yscale)
+						z := cd.New(0, 0)
+						_, err = ctx.Add(z, x, y)
+					}
+					resultMu.Lock()
+					resultError = err
+					resultMu.Unlock()
+				})
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					var z ss.Decimal
+					for pb.Next() {
+						x := ss.New(tt.xcoef, -tt.xscale)
+						y := ss.New(tt.ycoef, -tt.yscale)
+						z = x.Add(y)
+					}
+					resultMu.Lock()
+					resultSS = z
+					resultMu.Unlock()
+				})
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_MulParallel(b *testing.B) {
+	tests := parallelSizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					var z gv.Decimal
+					var err error
+					for pb.Next() {
+						x := gv.MustNew(tt.xcoef, int(tt.xscale))
+						y := gv.MustNew(tt.ycoef, int(tt.yscale))
+						z, err = x.Mul(y)
+					}
+					resultMu.Lock()
+					resultGV, resultError = z, err
+					resultMu.Unlock()
+				})
+			})
+
+			// Every goroutine has its own context.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					ctx := cd.BaseContext
+					ctx.Precision = 19
+					ctx.Rounding = cd.RoundHalfEven
+					var err error
+					for pb.Next() {
+						x := cd.New(tt.xcoef, -tt.xscale)
+						y := cd.New(tt.ycoef, -tt.yscale)
+						z := cd.New(0, 0)
+						_, err = ctx.Mul(z, x, y)
+					}
+					resultMu.Lock()
+					resultError = err
+					resultMu.Unlock()
+				})
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					var z ss.Decimal
+					for pb.Next() {
+						x := ss.New(tt.xcoef, -tt.xscale)
+						y := ss.New(tt.ycoef, -tt.yscale)
+						z = x.Mul(y)
+					}
+					resultMu.Lock()
+					resultSS = z
+					resultMu.Unlock()
+				})
+			})
+		})
+	}
+}
+
+func BenchmarkDecimal_QuoParallel(b *testing.B) {
+	tests := parallelSizes
+	for name, tt := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					var z gv.Decimal
+					var err error
+					for pb.Next() {
+						x := gv.MustNew(tt.xcoef, int(tt.xscale))
+						y := gv.MustNew(tt.ycoef, int(tt.yscale))
+						z, err = x.Quo(y)
+					}
+					resultMu.Lock()
+					resultGV, resultError = z, err
+					resultMu.Unlock()
+				})
+			})
+
+			// Every goroutine has its own context.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				runParallel(b, func(pb *testing.PB) {
+					ctx := cd.BaseContext
+					ctx.Precision = 19
+					ctx.Rounding = cd.RoundHalfEven
+					var err error
+					for pb.Next() {
+						x := cd.New(tt.xcoef, -tt.xscale)
+						y := cd.New(tt.ycoef, -tt.yscale)
+						z := cd.New(0, 0)
+						_, err = ctx.Quo(z, x, y)
+					}
+					resultMu.Lock()
+					resultError = err
+					resultMu.Unlock()
+				})
+			})
+
+			// The division precision is a global setting.
+			b.Run("mod=shopspring", func(b *testing.B) {
+				ss.DivisionPrecision = 19
+				runParallel(b, func(pb *testing.PB) {
+					var z ss.Decimal
+					for pb.Next() {
+						x := ss.New(tt.xcoef, -tt.xscale)
+						y := ss.New(tt.ycoef, -tt.yscale)
+						z = x.Div(y)
+					}
+					resultMu.Lock()
+					resultSS = z
+					resultMu.Unlock()
+				})
+			})
+		})
+	}
+}
+
+// BenchmarkDecimal_TelcoParallel runs the Telco workload with a separate
+// processor and totals in every goroutine.
+func BenchmarkDecimal_TelcoParallel(b *testing.B) {
+	tests, _, err := loadTelcoTests()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("mod=govalues", func(b *testing.B) {
+		runParallel(b, func(pb *testing.PB) {
+			runTelco(b, pb, newTelcoGV(io.Discard), tests)
+		})
+	})
+
+	b.Run("mod=cockroachdb", func(b *testing.B) {
+		runParallel(b, func(pb *testing.PB) {
+			runTelco(b, pb, newTelcoCD(io.Discard), tests)
+		})
+	})
+
+	b.Run("mod=shopspring", func(b *testing.B) {
+		runParallel(b, func(pb *testing.PB) {
+			runTelco(b, pb, newTelcoSS(io.Discard), tests)
+		})
+	})
+}
+
+func runTelco(b *testing.B, pb *testing.PB, t telco, tests []telcoRecord) {
+	for i := 0; pb.Next(); i++ {
+		err := t.call(tests[i%len(tests)])
+		if err != nil {
+			b.Error(err)
+			return
+		}
+	}
+}
@@ -0,0 +1,171 @@
+package decimal_test
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+)
+
+// Hostile inputs, as they may arrive from untrusted JSON or CSV.
+// Most of them are rejected by govalues, but accepted by other libraries,
+// which store arbitrary-precision coefficients and exponents.
+var adversarialInputs = map[string]string{
+	"input=digits10k":    strings.Repeat("7", 10_000),
+	"input=zeros10k":     strings.Repeat("0", 10_000) + "1",
+	"input=fraction10k":  "0." + strings.Repeat("1", 10_000),
+	"input=trailing10k":  "1." + strings.Repeat("0", 10_000),
+	"input=minexp":       "1e-999999999",
+	"input=maxexp":       "1e999999999",
+	"input=overflowexp":  "1e+99999999999999999999",
+	"input=twopoints":    "1.2.3",
+	"input=twosigns":     "--1",
+	"input=noexp":        "1e",
+	"input=letters":      "abc",
+	"input=empty":        "",
+	"input=underscore":   "1_000",
+	"input=leadingspace": " 1",
+}
+
+func BenchmarkParseAdversarial(b *testing.B) {
+	tests := adversarialInputs
+	for name, s := range tests {
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				for range b.N {
+					resultGV, resultError = gv.Parse(s)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := cd.New(0, 0)
+				for range b.N {
+					_, _, resultError = d.SetString(s)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				for range b.N {
+					resultSS, resultError = ss.NewFromString(s)
+				}
+			})
+		})
+	}
+}
+
+var parseTiming = flag.Bool("parse.timing", false, "run the timing test of hostile inputs")
+
+// Bounds of TestParseAdversarial.
+const (
+	// parseMaxGrowth is the maximum growth exponent of linear parsing.
+	// It rejects quadratic growth, as well as growth of length^1.5.
+	parseMaxGrowth = 1.3
+	// parseMaxDigitsTime is the maximum time of parsing 10,000 significant
+	// digits by libraries with arbitrary-precision coefficients.
+	parseMaxDigitsTime = 10 * time.Millisecond
+	// parseMaxShortTime is the maximum time of parsing a short input.
+	parseMaxShortTime = time.Millisecond
+)
+
+// TestParseAdversarial checks that the parsing time of hostile inputs
+// is linear, or bounded, in the input length.
+// The growth is measured as the exponent k in time ~ length^k,
+// when the input becomes 16 times longer.
+// Wall-clock times depend on the machine load, so the test runs only
+// with the -parse.timing flag.
+//
+// Parsing of leading zeros must be linear in every library.
+// Trailing zeros after the decimal point are significant digits, since
+// they are kept in the coefficient.
+// Parsing of significant digits must be linear in govalues, which rejects
+// coefficients with more than 19 digits after reading at most 19 digits.
+// In other libraries it is bounded by the conversion of decimal strings
+// in math/big, which is superlinear, so only the time of parsing
+// 10,000 digits is checked.
+//
+// Short inputs, such as huge exponents and malformed strings,
+// must be parsed or rejected in bounded time.
+func TestParseAdversarial(t *testing.T) {
+	if !*parseTiming {
+		t.Skip("skipping timing test, use -parse.timing to run it")
+	}
+
+	parsers := []struct {
+		name  string
+		parse func(string)
+		// significant digits are parsed in linear time
+		linear bool
+	}{
+		{"govalues", func(s string) { _, _ = gv.Parse(s) }, true},
+		{"cockroachdb", func(s string) { _, _, _ = cd.NewFromString(s) }, false},
+		{"shopspring", func(s string) { _, _ = ss.NewFromString(s) }, false},
+	}
+
+	long := []struct {
+		name   string
+		input  func(n int) string
+		digits bool
+	}{
+		{"digits", func(n int) string { return strings.Repeat("7", n) }, true},
+		{"fraction", func(n int) string { return "0." + strings.Repeat("1", n) }, true},
+		{"zeros", func(n int) string { return strings.Repeat("0", n) + "1" }, false},
+		{"trailing", func(n int) string { return "1." + strings.Repeat("0", n) }, true},
+		{"tiny", func(n int) string { return "0." + strings.Repeat("0", n) + "1" }, false},
+	}
+
+	for _, p := range parsers {
+		for _, tt := range long {
+			t.Run(fmt.Sprintf("%v/%v", p.name, tt.name), func(t *testing.T) {
+				if tt.digits && !p.linear {
+					if got := parseTime(p.parse, tt.input(10_000)); got > parseMaxDigitsTime {
+						t.Errorf("parsing 10k digits takes %v, want at most %v", got, parseMaxDigitsTime)
+					}
+					return
+				}
+				short := parseTime(p.parse, tt.input(4_000))
+				long := parseTime(p.parse, tt.input(64_000))
+				// Times below the timer resolution are considered bounded.
+				if long < 10*time.Microsecond {
+					return
+				}
+				growth := math.Log(float64(long)/float64(short)) / math.Log(16)
+				if growth > parseMaxGrowth {
+					t.Errorf("parsing time grows as length^%.2f (%v for 4k, %v for 64k), want at most length^%v",
+						growth, short, long, parseMaxGrowth)
+				}
+			})
+		}
+
+		t.Run(fmt.Sprintf("%v/short", p.name), func(t *testing.T) {
+			for name, s := range adversarialInputs {
+				if len(s) > 100 {
+					continue
+				}
+				if got := parseTime(p.parse, s); got > parseMaxShortTime {
+					t.Errorf("parsing %v takes %v, want at most %v", name, got, parseMaxShortTime)
+				}
+			}
+		})
+	}
+}
+
+// parseTime returns the median of 9 average times of 10 calls of parse(s).
+func parseTime(parse func(string), s string) time.Duration {
+	var samples [9]time.Duration
+	for i := range samples {
+		start := time.Now()
+		for range 10 {
+			parse(s)
+		}
+		samples[i] = time.Since(start) / 10
+	}
+	slices.Sort(samples[:])
+	return samples[len(samples)/2]
+}
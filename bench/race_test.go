@@ -0,0 +1,7 @@
+//go:build race
+
+package decimal_test
+
+func init() {
+	raceEnabled = true
+}
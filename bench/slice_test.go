@@ -0,0 +1,249 @@
+package decimal_test
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+)
+
+// Slice lengths of batch benchmarks.
+// Allocations are always reported, so that the garbage collection costs
+// of value types (govalues, shopspring) and pointer types (cockroachdb)
+// can be compared.
+var sliceSizes = map[string]int{
+	"size=1k":   1_000,
+	"size=100k": 100_000,
+	"size=1M":   1_000_000,
+}
+
+// newSlice returns n monetary amounts between -1000000.00 and 1000000.00.
+// The amounts are generated with a fixed seed, so that every library and
+// every run gets the same data.
+func newSlice(n int) []fixed {
+	random := rand.New(rand.NewPCG(1, 3))
+	s := make([]fixed, n)
+	for i := range s {
+		s[i] = fixed{random.Int64N(200_000_001) - 100_000_000, 2}
+	}
+	return s
+}
+
+func newSliceGV(s []fixed) []gv.Decimal {
+	d := make([]gv.Decimal, len(s))
+	for i, c := range s {
+		d[i] = gv.MustNew(c.coef, int(c.scale))
+	}
+	return d
+}
+
+func newSliceCD(s []fixed) []*cd.Decimal {
+	d := make([]*cd.Decimal, len(s))
+	for i, c := range s {
+		d[i] = cd.New(c.coef, -c.scale)
+	}
+	return d
+}
+
+func newSliceSS(s []fixed) []ss.Decimal {
+	d := make([]ss.Decimal, len(s))
+	for i, c := range s {
+		d[i] = ss.New(c.coef, -c.scale)
+	}
+	return d
+}
+
+func BenchmarkSlice_Sum(b *testing.B) {
+	tests := sliceSizes
+	for name, n := range tests {
+		b.Run(name, func(b *testing.B) {
+			s := newSlice(n)
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := newSliceGV(s)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					resultGV, resultError = gv.Sum(d...)
+				}
+			})
+
+			// There is no sum function, the decimals are added one by one.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				ctx := cd.BaseContext
+				ctx.Precision = 19
+				ctx.Rounding = cd.RoundHalfEven
+				d := newSliceCD(s)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					f := cd.New(0, 0)
+					for _, e := range d {
+						_, resultError = ctx.Add(f, f, e)
+					}
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := newSliceSS(s)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					resultSS = ss.Sum(d[0], d[1:]...)
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkSlice_Mean(b *testing.B) {
+	tests := sliceSizes
+	for name, n := range tests {
+		b.Run(name, func(b *testing.B) {
+			s := newSlice(n)
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := newSliceGV(s)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					resultGV, resultError = gv.Mean(d...)
+				}
+			})
+
+			// There is no mean function, the sum is divided by the count.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				ctx := cd.BaseContext
+				ctx.Precision = 19
+				ctx.Rounding = cd.RoundHalfEven
+				d := newSliceCD(s)
+				count := cd.New(int64(len(d)), 0)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					f := cd.New(0, 0)
+					for _, e := range d {
+						_, resultError = ctx.Add(f, f, e)
+					}
+					_, resultError = ctx.Quo(f, f, count)
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				ss.DivisionPrecision = 19
+				d := newSliceSS(s)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for range b.N {
+					resultSS = ss.Avg(d[0], d[1:]...)
+				}
+			})
+		})
+	}
+}
+
+// BenchmarkSlice_SortFunc sorts a shuffled slice with slices.SortFunc and Cmp.
+// Restoring the unsorted order between iterations is not measured.
+func BenchmarkSlice_SortFunc(b *testing.B) {
+	tests := sliceSizes
+	for name, n := range tests {
+		b.Run(name, func(b *testing.B) {
+			s := newSlice(n)
+			b.Run("mod=govalues", func(b *testing.B) {
+				resultGV = benchSort(b, newSliceGV(s), gv.Decimal.Cmp)
+			})
+
+			// Only the pointers are moved.
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				resultString = benchSort(b, newSliceCD(s), (*cd.Decimal).Cmp).String()
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				resultSS = benchSort(b, newSliceSS(s), ss.Decimal.Cmp)
+			})
+		})
+	}
+}
+
+// sortBatch is the number of elements copied between two runs of the timer
+// in benchSort.
+const sortBatch = 1_000_000
+
+// benchSort sorts copies of d and returns the smallest element.
+// The copies are made in batches with the timer stopped, so that stopping
+// the timer does not distort the time of sorting short slices.
+func benchSort[T any](b *testing.B, d []T, cmp func(T, T) int) T {
+	batch := make([][]T, max(1, sortBatch/len(d)))
+	for i := range batch {
+		batch[i] = make([]T, len(d))
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; {
+		k := min(len(batch), b.N-i)
+		b.StopTimer()
+		for _, e := range batch[:k] {
+			copy(e, d)
+		}
+		b.StartTimer()
+		for _, e := range batch[:k] {
+			slices.SortFunc(e, cmp)
+		}
+		i += k
+	}
+	return batch[0][0]
+}
+
+// BenchmarkSlice_MapKey measures a single lookup in a map that holds every
+// decimal of the slice.
+// Decimals of govalues are comparable and are used as keys directly,
+// provided that they have the same scale.
+// Decimals of other libraries are not comparable, so their string
+// representations are used as keys.
+func BenchmarkSlice_MapKey(b *testing.B) {
+	tests := sliceSizes
+	for name, n := range tests {
+		b.Run(name, func(b *testing.B) {
+			s := newSlice(n)
+			b.Run("mod=govalues", func(b *testing.B) {
+				d := newSliceGV(s)
+				m := make(map[gv.Decimal]int, len(d))
+				for i, e := range d {
+					m[e] = i
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					resultInt, resultBool = m[d[i%len(d)]]
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				d := newSliceCD(s)
+				m := make(map[string]int, len(d))
+				for i, e := range d {
+					m[e.String()] = i
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					resultInt, resultBool = m[d[i%len(d)].String()]
+				}
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				d := newSliceSS(s)
+				m := make(map[string]int, len(d))
+				for i, e := range d {
+					m[e.String()] = i
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := range b.N {
+					resultInt, resultBool = m[d[i%len(d)].String()]
+				}
+			})
+		})
+	}
+}
@@ -0,0 +1,598 @@
+package decimal_test
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand/v2"
+	"os"
+	"testing"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+)
+
+// telcoFile is the input file of the Telco benchmark.
+// It contains one million call durations as 8-byte packed decimals.
+// The file can be downloaded from the [Telco benchmark] page.
+//
+// [Telco benchmark]: https://speleotrove.com/decimal/telco.html
+const telcoFile = "expon180.1e6b"
+
+// telcoSums are the published totals for telcoFile:
+// the total price, the total base tax, and the total distance tax.
+var telcoSums = [3]string{"1004737.58", "57628.30", "25042.17"}
+
+// telcoShortCount is the number of records processed by TestTelco
+// in short mode.
+const telcoShortCount = 10000
+
+// If telcoFile is missing, a synthetic dataset is generated instead.
+const (
+	telcoDefaultSeed  = 1
+	telcoDefaultCount = 1000000
+)
+
+var (
+	telcoSeed  = flag.Uint64("telco.seed", telcoDefaultSeed, "seed of the synthetic Telco dataset")
+	telcoCount = flag.Int("telco.count", telcoDefaultCount, "number of records in the synthetic Telco dataset")
+)
+
+// telcoSyntheticSums are the totals for the synthetic dataset
+// with the default seed and count.
+// TestTelco_syntheticSums derives them with integer arithmetic on cents,
+// independently of the implementations that are benchmarked.
+var telcoSyntheticSums = [3]string{"999956.20", "57344.35", "24904.77"}
+
+// BenchmarkDecimal_Telco implements "[Telco benchmark]" by Mike Cowlishaw.
+// Every iteration decodes one packed decimal record, computes the price
+// and taxes of the call, and writes the formatted price to a buffered output.
+//
+// [Telco benchmark]: https://speleotrove.com/decimal/telco.html
+func BenchmarkDecimal_Telco(b *testing.B) {
+	tests, _, err := loadTelcoTests()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("mod=govalues", func(b *testing.B) {
+		t := newTelcoGV(io.Discard)
+		b.ResetTimer()
+		for i := range b.N {
+			err := t.call(tests[i%len(tests)])
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("mod=cockroachdb", func(b *testing.B) {
+		t := newTelcoCD(io.Discard)
+		b.ResetTimer()
+		for i := range b.N {
+			err := t.call(tests[i%len(tests)])
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("mod=shopspring", func(b *testing.B) {
+		t := newTelcoSS(io.Discard)
+		b.ResetTimer()
+		for i := range b.N {
+			err := t.call(tests[i%len(tests)])
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("mod=int64", func(b *testing.B) {
+		t := newTelcoInt64(io.Discard)
+		b.ResetTimer()
+		for i := range b.N {
+			err := t.call(tests[i%len(tests)])
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	// The drift of the totals over all records is reported in currency units.
+	var drift *[3]float64
+	b.Run("mod=float64", func(b *testing.B) {
+		t := newTelcoFloat64(io.Discard)
+		b.ResetTimer()
+		for i := range b.N {
+			err := t.call(tests[i%len(tests)])
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+		b.StopTimer()
+		if drift == nil {
+			d, err := telcoDrift(tests)
+			if err != nil {
+				b.Fatal(err)
+			}
+			drift = &d
+		}
+		b.ReportMetric(drift[0], "total-drift")
+		b.ReportMetric(drift[1], "basetax-drift")
+		b.ReportMetric(drift[2], "disttax-drift")
+	})
+}
+
+// TestTelco processes every record of the Telco input once and compares
+// the totals with the reference sums and the outputs of the libraries
+// with each other.
+// In short mode, only the first records are processed and the totals
+// are not checked.
+func TestTelco(t *testing.T) {
+	tests, want, err := loadTelcoTests()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testing.Short() {
+		tests, want = tests[:min(len(tests), telcoShortCount)], nil
+	}
+
+	var outGV, outCD, outSS, outInt64 bytes.Buffer
+	libs := []struct {
+		name string
+		t    telco
+		out  *bytes.Buffer
+	}{
+		{"govalues", newTelcoGV(&outGV), &outGV},
+		{"cockroachdb", newTelcoCD(&outCD), &outCD},
+		{"shopspring", newTelcoSS(&outSS), &outSS},
+		{"int64", newTelcoInt64(&outInt64), &outInt64},
+	}
+	for _, lib := range libs {
+		for _, tt := range tests {
+			err := lib.t.call(tt)
+			if err != nil {
+				t.Fatalf("%v: call(%x) failed: %v", lib.name, tt, err)
+			}
+		}
+		got, err := lib.t.sums()
+		if err != nil {
+			t.Fatalf("%v: sums() failed: %v", lib.name, err)
+		}
+		if want != nil && got != *want {
+			t.Errorf("%v: sums() = %q, want %q", lib.name, got, *want)
+		}
+	}
+	if !bytes.Equal(outGV.Bytes(), outCD.Bytes()) {
+		t.Errorf("outputs of govalues and cockroachdb differ")
+	}
+	if !bytes.Equal(outGV.Bytes(), outSS.Bytes()) {
+		t.Errorf("outputs of govalues and shopspring differ")
+	}
+	if !bytes.Equal(outGV.Bytes(), outInt64.Bytes()) {
+		t.Errorf("outputs of govalues and int64 differ")
+	}
+}
+
+// TestTelco_syntheticSums computes the totals of the synthetic dataset
+// with integer arithmetic on cents and compares them with telcoSyntheticSums.
+func TestTelco_syntheticSums(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping one million records in short mode")
+	}
+	// roundHalfEven returns a / b rounded half to even, for non-negative a.
+	roundHalfEven := func(a, b int64) int64 {
+		q, r := a/b, a%b
+		if 2*r > b || 2*r == b && q%2 == 1 {
+			q++
+		}
+		return q
+	}
+	var sumT, sumB, sumD int64
+	for _, rec := range generateTelcoTests(telcoDefaultSeed, telcoDefaultCount) {
+		n, err := rec.duration()
+		if err != nil {
+			t.Fatal(err)
+		}
+		var price int64
+		if n%2 == 0 {
+			price = roundHalfEven(n*13, 100) // n × 0.0013
+		} else {
+			price = roundHalfEven(n*894, 1000) // n × 0.00894
+		}
+		baseTax := price * 675 / 10000 // price × 0.0675, truncated
+		sumB += baseTax
+		sumT += price + baseTax
+		if n%2 != 0 {
+			distTax := price * 341 / 10000 // price × 0.0341, truncated
+			sumD += distTax
+			sumT += distTax
+		}
+	}
+	cents := func(c int64) string {
+		return fmt.Sprintf("%d.%02d", c/100, c%100)
+	}
+	got := [3]string{cents(sumT), cents(sumB), cents(sumD)}
+	if got != telcoSyntheticSums {
+		t.Errorf("sums = %q, want %q", got, telcoSyntheticSums)
+	}
+}
+
+// telco is an implementation of the Telco workload.
+type telco interface {
+	// call processes a single record and writes the price to the output.
+	call(rec telcoRecord) error
+	// sums flushes the output and returns the totals formatted with 2 digits
+	// after the decimal point.
+	sums() ([3]string, error)
+}
+
+// telcoRecord is an 8-byte packed decimal with 15 digits and a sign nibble.
+type telcoRecord [8]byte
+
+// duration decodes the call duration in seconds.
+func (r telcoRecord) duration() (int64, error) {
+	var n int64
+	for i := range 15 {
+		digit := r[i/2]
+		if i%2 == 0 {
+			digit >>= 4
+		}
+		digit &= 0x0f
+		if digit > 9 {
+			return 0, fmt.Errorf("invalid digit %x in record %x", digit, r)
+		}
+		n = n*10 + int64(digit)
+	}
+	switch sign := r[7] & 0x0f; sign {
+	case 0x0a, 0x0c, 0x0e, 0x0f:
+		return n, nil
+	case 0x0b, 0x0d:
+		return -n, nil
+	default:
+		return 0, fmt.Errorf("invalid sign %x in record %x", sign, r)
+	}
+}
+
+type telcoGV struct {
+	out                                          *bufio.Writer
+	baseRate, distRate, baseTaxRate, distTaxRate gv.Decimal
+	sumT, sumB, sumD                             gv.Decimal
+}
+
+func newTelcoGV(w io.Writer) *telcoGV {
+	return &telcoGV{
+		out:         bufio.NewWriter(w),
+		baseRate:    gv.MustParse("0.0013"),
+		distRate:    gv.MustParse("0.00894"),
+		baseTaxRate: gv.MustParse("0.0675"),
+		distTaxRate: gv.MustParse("0.0341"),
+	}
+}
+
+func (t *telcoGV) call(rec telcoRecord) error {
+	n, err := rec.duration()
+	if err != nil {
+		return err
+	}
+	callType := n & 0x01
+
+	// Duration, Seconds
+	duration, err := gv.New(n, 0)
+	if err != nil {
+		return err
+	}
+
+	// Price
+	var price gv.Decimal
+	if callType == 0 {
+		price, err = duration.Mul(t.baseRate)
+	} else {
+		price, err = duration.Mul(t.distRate)
+	}
+	if err != nil {
+		return err
+	}
+	price = price.Round(2)
+
+	// Base Tax
+	baseTax, err := price.Mul(t.baseTaxRate)
+	if err != nil {
+		return err
+	}
+	baseTax = baseTax.Trunc(2)
+	t.sumB, err = t.sumB.Add(baseTax)
+	if err != nil {
+		return err
+	}
+	finalPrice, err := price.Add(baseTax)
+	if err != nil {
+		return err
+	}
+
+	// Distance Tax
+	if callType != 0 {
+		distTax, err := price.Mul(t.distTaxRate)
+		if err != nil {
+			return err
+		}
+		distTax = distTax.Trunc(2)
+		t.sumD, err = t.sumD.Add(distTax)
+		if err != nil {
+			return err
+		}
+		finalPrice, err = finalPrice.Add(distTax)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Final Price
+	t.sumT, err = t.sumT.Add(finalPrice)
+	if err != nil {
+		return err
+	}
+	t.out.WriteString(finalPrice.Pad(2).String())
+	return t.out.WriteByte('\n')
+}
+
+func (t *telcoGV) sums() ([3]string, error) {
+	return [3]string{
+		t.sumT.Pad(2).String(),
+		t.sumB.Pad(2).String(),
+		t.sumD.Pad(2).String(),
+	}, t.out.Flush()
+}
+
+type telcoCD struct {
+	out                                          *bufio.Writer
+	round, trunc                                 cd.Context
+	baseRate, distRate, baseTaxRate, distTaxRate *cd.Decimal
+	sumT, sumB, sumD                             *cd.Decimal
+}
+
+func newTelcoCD(w io.Writer) *telcoCD {
+	round := cd.BaseContext
+	round.Precision = 19
+	round.Rounding = cd.RoundHalfEven
+	trunc := round
+	trunc.Rounding = cd.RoundDown
+	return &telcoCD{
+		out:         bufio.NewWriter(w),
+		round:       round,
+		trunc:       trunc,
+		baseRate:    cd.New(13, -4),  // 0.0013
+		distRate:    cd.New(894, -5), // 0.00894
+		baseTaxRate: cd.New(675, -4), // 0.0675
+		distTaxRate: cd.New(341, -4), // 0.0341
+		sumT:        cd.New(0, -2),
+		sumB:        cd.New(0, -2),
+		sumD:        cd.New(0, -2),
+	}
+}
+
+func (t *telcoCD) call(rec telcoRecord) error {
+	n, err := rec.duration()
+	if err != nil {
+		return err
+	}
+	callType := n & 0x01
+
+	// Duration, Seconds
+	duration := cd.New(n, 0)
+
+	// Price
+	price := new(cd.Decimal)
+	if callType == 0 {
+		_, err = t.round.Mul(price, duration, t.baseRate)
+	} else {
+		_, err = t.round.Mul(price, duration, t.distRate)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = t.round.Quantize(price, price, -2)
+	if err != nil {
+		return err
+	}
+
+	// Base Tax
+	baseTax := new(cd.Decimal)
+	_, err = t.round.Mul(baseTax, price, t.baseTaxRate)
+	if err != nil {
+		return err
+	}
+	_, err = t.trunc.Quantize(baseTax, baseTax, -2)
+	if err != nil {
+		return err
+	}
+	_, err = t.round.Add(t.sumB, t.sumB, baseTax)
+	if err != nil {
+		return err
+	}
+	finalPrice := new(cd.Decimal)
+	_, err = t.round.Add(finalPrice, price, baseTax)
+	if err != nil {
+		return err
+	}
+
+	// Distance Tax
+	if callType != 0 {
+		distTax := new(cd.Decimal)
+		_, err = t.round.Mul(distTax, price, t.distTaxRate)
+		if err != nil {
+			return err
+		}
+		_, err = t.trunc.Quantize(distTax, distTax, -2)
+		if err != nil {
+			return err
+		}
+		_, err = t.round.Add(t.sumD, t.sumD, distTax)
+		if err != nil {
+			return err
+		}
+		_, err = t.round.Add(finalPrice, finalPrice, distTax)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Final Price
+	_, err = t.round.Add(t.sumT, t.sumT, finalPrice)
+	if err != nil {
+		return err
+	}
+	t.out.WriteString(finalPrice.Text('f'))
+	return t.out.WriteByte('\n')
+}
+
+func (t *telcoCD) sums() ([3]string, error) {
+	return [3]string{
+		t.sumT.Text('f'),
+		t.sumB.Text('f'),
+		t.sumD.Text('f'),
+	}, t.out.Flush()
+}
+
+type telcoSS struct {
+	out                                          *bufio.Writer
+	baseRate, distRate, baseTaxRate, distTaxRate ss.Decimal
+	sumT, sumB, sumD                             ss.Decimal
+}
+
+func newTelcoSS(w io.Writer) *telcoSS {
+	return &telcoSS{
+		out:         bufio.NewWriter(w),
+		baseRate:    ss.RequireFromString("0.0013"),
+		distRate:    ss.RequireFromString("0.00894"),
+		baseTaxRate: ss.RequireFromString("0.0675"),
+		distTaxRate: ss.RequireFromString("0.0341"),
+	}
+}
+
+func (t *telcoSS) call(rec telcoRecord) error {
+	n, err := rec.duration()
+	if err != nil {
+		return err
+	}
+	callType := n & 0x01
+
+	// Duration, Seconds
+	duration := ss.NewFromInt(n)
+
+	// Price
+	var price ss.Decimal
+	if callType == 0 {
+		price = duration.Mul(t.baseRate)
+	} else {
+		price = duration.Mul(t.distRate)
+	}
+	price = price.RoundBank(2)
+
+	// Base Tax
+	baseTax := price.Mul(t.baseTaxRate)
+	baseTax = baseTax.RoundDown(2)
+	t.sumB = t.sumB.Add(baseTax)
+	finalPrice := price.Add(baseTax)
+
+	// Distance Tax
+	if callType != 0 {
+		distTax := price.Mul(t.distTaxRate)
+		distTax = distTax.RoundDown(2)
+		t.sumD = t.sumD.Add(distTax)
+		finalPrice = finalPrice.Add(distTax)
+	}
+
+	// Final Price
+	t.sumT = t.sumT.Add(finalPrice)
+	t.out.WriteString(finalPrice.StringFixed(2))
+	return t.out.WriteByte('\n')
+}
+
+func (t *telcoSS) sums() ([3]string, error) {
+	return [3]string{
+		t.sumT.StringFixed(2),
+		t.sumB.StringFixed(2),
+		t.sumD.StringFixed(2),
+	}, t.out.Flush()
+}
+
+// loadTelcoTests returns the records of telcoFile and the published sums.
+// If the file is missing, it returns a synthetic dataset and its sums,
+// which are known only for the default seed and count.
+func loadTelcoTests() ([]telcoRecord, *[3]string, error) {
+	tests, err := readTelcoTests()
+	if err == nil {
+		return tests, &telcoSums, nil
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, err
+	}
+	if *telcoCount <= 0 {
+		return nil, nil, fmt.Errorf("-telco.count must be positive, got %v", *telcoCount)
+	}
+	tests = generateTelcoTests(*telcoSeed, *telcoCount)
+	if *telcoSeed == telcoDefaultSeed && *telcoCount == telcoDefaultCount {
+		return tests, &telcoSyntheticSums, nil
+	}
+	return tests, nil, nil
+}
+
+func readTelcoTests() ([]telcoRecord, error) {
+	file, err := os.Open(telcoFile)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	data := make([]telcoRecord, 0, 1000000)
+	r := bufio.NewReader(file)
+	var rec telcoRecord
+	for {
+		_, err := io.ReadFull(r, rec[:])
+		if err == io.EOF {
+			break
+		}
+		if err == io.ErrUnexpectedEOF {
+			return nil, fmt.Errorf("%v: truncated record %v", telcoFile, len(data))
+		}
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, rec)
+	}
+	return data, nil
+}
+
+// generateTelcoTests returns count records with call durations drawn from
+// an exponential distribution with a mean of 180 seconds, like in telcoFile.
+// As in telcoFile, the lowest bit of a duration is the call type.
+func generateTelcoTests(seed uint64, count int) []telcoRecord {
+	random := rand.New(rand.NewPCG(seed, seed))
+	data := make([]telcoRecord, count)
+	for i := range data {
+		data[i] = newTelcoRecord(int64(random.ExpFloat64() * 180))
+	}
+	return data
+}
+
+// newTelcoRecord encodes a non-negative duration as a packed decimal.
+func newTelcoRecord(n int64) telcoRecord {
+	var r telcoRecord
+	r[7] = 0x0c // positive sign
+	for i := 14; i >= 0; i-- {
+		digit := byte(n % 10)
+		n /= 10
+		if i%2 == 0 {
+			r[i/2] |= digit << 4
+		} else {
+			r[i/2] |= digit
+		}
+	}
+	return r
+}
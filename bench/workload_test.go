@@ -0,0 +1,600 @@
+package decimal_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+)
+
+// workload is an end-to-end computation that returns its totals as text.
+type workload struct {
+	name string
+	want string
+	gv   func() (string, error)
+	cd   func() (string, error)
+	ss   func() (string, error)
+}
+
+var workloads = []workload{
+	{
+		name: "Loan",
+		want: "interest=318861.58 paid=568861.58 balance=0.00",
+		gv:   loanGV,
+		cd:   loanCD,
+		ss:   loanSS,
+	},
+	{
+		name: "FX",
+		want: "AUD=23769254991.98 CAD=28472169072.10 CHF=41359137187.63 CNY=5329361843.43 " +
+			"EUR=44051484501.58 GBP=44664111386.04 JPY=250931685.68 SEK=3522408452.67 " +
+			"total=191418859121.11",
+		gv: fxGV,
+		cd: fxCD,
+		ss: fxSS,
+	},
+	{
+		name: "OrderBook",
+		want: "filled=236.750 notional=23836.35000 unfilled=363.150 levels=137",
+		gv:   orderBookGV,
+		cd:   orderBookCD,
+		ss:   orderBookSS,
+	},
+}
+
+// BenchmarkWorkload runs a workload once per iteration and verifies
+// the totals of the last iteration.
+func BenchmarkWorkload(b *testing.B) {
+	for _, w := range workloads {
+		b.Run(w.name, func(b *testing.B) {
+			b.Run("mod=govalues", func(b *testing.B) {
+				benchWorkload(b, w.gv, w.want)
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				benchWorkload(b, w.cd, w.want)
+			})
+
+			b.Run("mod=shopspring", func(b *testing.B) {
+				ss.DivisionPrecision = 19
+				benchWorkload(b, w.ss, w.want)
+			})
+		})
+	}
+}
+
+func benchWorkload(b *testing.B, fn func() (string, error), want string) {
+	for range b.N {
+		resultString, resultError = fn()
+	}
+	if resultError != nil {
+		b.Fatal(resultError)
+	}
+	if resultString != want {
+		b.Fatalf("totals = %q, want %q", resultString, want)
+	}
+}
+
+func TestWorkloads(t *testing.T) {
+	ss.DivisionPrecision = 19
+	for _, w := range workloads {
+		for _, lib := range []struct {
+			name string
+			fn   func() (string, error)
+		}{
+			{"govalues", w.gv},
+			{"cockroachdb", w.cd},
+			{"shopspring", w.ss},
+		} {
+			got, err := lib.fn()
+			if err != nil {
+				t.Errorf("%v/%v failed: %v", w.name, lib.name, err)
+				continue
+			}
+			if got != w.want {
+				t.Errorf("%v/%v = %q, want %q", w.name, lib.name, got, w.want)
+			}
+		}
+	}
+}
+
+func fixedGV(d gv.Decimal, scale int) string {
+	return d.Pad(scale).String()
+}
+
+func fixedCD(d *cd.Decimal, scale int) (string, error) {
+	ctx := cd.BaseContext
+	ctx.Precision = 19
+	ctx.Rounding = cd.RoundHalfEven
+	f := new(cd.Decimal)
+	_, err := ctx.Quantize(f, d, int32(-scale))
+	if err != nil {
+		return "", err
+	}
+	return f.Text('f'), nil
+}
+
+func fixedSS(d ss.Decimal, scale int) string {
+	return d.StringFixed(int32(scale))
+}
+
+// Loan amortization schedule.
+// A fixed monthly payment is split into the interest, accrued on
+// the outstanding balance and rounded to cents, and the principal.
+// The last payment repays the remaining balance.
+const loanPeriods = 360
+
+var (
+	loanPrincipal = fixed{25000000, 2} // 250000.00
+	loanRate      = fixed{65, 3}       // 6.5% per year
+	loanPayment   = fixed{158017, 2}   // 1580.17 per month
+)
+
+func loanGV() (string, error) {
+	balance := gv.MustNew(loanPrincipal.coef, int(loanPrincipal.scale))
+	rate := gv.MustNew(loanRate.coef, int(loanRate.scale))
+	payment := gv.MustNew(loanPayment.coef, int(loanPayment.scale))
+	months := gv.MustNew(12, 0)
+	totalInterest := gv.Zero
+	totalPaid := gv.Zero
+	for i := range loanPeriods {
+		// Interest
+		interest, err := balance.Mul(rate)
+		if err != nil {
+			return "", err
+		}
+		interest, err = interest.Quo(months)
+		if err != nil {
+			return "", err
+		}
+		interest = interest.Round(2)
+
+		// Principal
+		principal, err := payment.Sub(interest)
+		if err != nil {
+			return "", err
+		}
+		if i == loanPeriods-1 || principal.Cmp(balance) > 0 {
+			principal = balance
+		}
+		balance, err = balance.Sub(principal)
+		if err != nil {
+			return "", err
+		}
+
+		// Totals
+		totalInterest, err = totalInterest.Add(interest)
+		if err != nil {
+			return "", err
+		}
+		totalPaid, err = totalPaid.Add(principal)
+		if err != nil {
+			return "", err
+		}
+		totalPaid, err = totalPaid.Add(interest)
+		if err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("interest=%v paid=%v balance=%v",
+		fixedGV(totalInterest, 2), fixedGV(totalPaid, 2), fixedGV(balance, 2)), nil
+}
+
+func loanCD() (string, error) {
+	ctx := cd.BaseContext
+	ctx.Precision = 19
+	ctx.Rounding = cd.RoundHalfEven
+	balance := cd.New(loanPrincipal.coef, -loanPrincipal.scale)
+	rate := cd.New(loanRate.coef, -loanRate.scale)
+	payment := cd.New(loanPayment.coef, -loanPayment.scale)
+	months := cd.New(12, 0)
+	totalInterest := cd.New(0, 0)
+	totalPaid := cd.New(0, 0)
+	for i := range loanPeriods {
+		// Interest
+		interest := new(cd.Decimal)
+		_, err := ctx.Mul(interest, balance, rate)
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Quo(interest, interest, months)
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Quantize(interest, interest, -2)
+		if err != nil {
+			return "", err
+		}
+
+		// Principal
+		principal := new(cd.Decimal)
+		_, err = ctx.Sub(principal, payment, interest)
+		if err != nil {
+			return "", err
+		}
+		if i == loanPeriods-1 || principal.Cmp(balance) > 0 {
+			principal.Set(balance)
+		}
+		_, err = ctx.Sub(balance, balance, principal)
+		if err != nil {
+			return "", err
+		}
+
+		// Totals
+		_, err = ctx.Add(totalInterest, totalInterest, interest)
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Add(totalPaid, totalPaid, principal)
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Add(totalPaid, totalPaid, interest)
+		if err != nil {
+			return "", err
+		}
+	}
+	return fixedTotalsCD(2, "interest", totalInterest, "paid", totalPaid, "balance", balance)
+}
+
+func loanSS() (string, error) {
+	balance := ss.New(loanPrincipal.coef, -loanPrincipal.scale)
+	rate := ss.New(loanRate.coef, -loanRate.scale)
+	payment := ss.New(loanPayment.coef, -loanPayment.scale)
+	months := ss.New(12, 0)
+	totalInterest := ss.Zero
+	totalPaid := ss.Zero
+	for i := range loanPeriods {
+		// Interest
+		interest := balance.Mul(rate).Div(months).RoundBank(2)
+
+		// Principal
+		principal := payment.Sub(interest)
+		if i == loanPeriods-1 || principal.Cmp(balance) > 0 {
+			principal = balance
+		}
+		balance = balance.Sub(principal)
+
+		// Totals
+		totalInterest = totalInterest.Add(interest)
+		totalPaid = totalPaid.Add(principal).Add(interest)
+	}
+	return fmt.Sprintf("interest=%v paid=%v balance=%v",
+		fixedSS(totalInterest, 2), fixedSS(totalPaid, 2), fixedSS(balance, 2)), nil
+}
+
+// fixedTotalsCD formats pairs of names and totals like the other libraries.
+func fixedTotalsCD(scale int, pairs ...any) (string, error) {
+	parts := make([]string, 0, len(pairs)/2)
+	for i := 0; i < len(pairs); i += 2 {
+		s, err := fixedCD(pairs[i+1].(*cd.Decimal), scale)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, fmt.Sprintf("%v=%v", pairs[i], s))
+	}
+	return strings.Join(parts, " "), nil
+}
+
+// FX portfolio revaluation.
+// Positions in foreign currencies are converted at the current rates,
+// rounded to cents, and summed per currency and in total.
+var (
+	fxCurrencies = []string{"AUD", "CAD", "CHF", "CNY", "EUR", "GBP", "JPY", "SEK"}
+	fxRates      = []fixed{
+		{658921, 6},  // AUD
+		{737412, 6},  // CAD
+		{1132045, 6}, // CHF
+		{138456, 6},  // CNY
+		{1084523, 6}, // EUR
+		{1267810, 6}, // GBP
+		{6712, 6},    // JPY
+		{95123, 6},   // SEK
+	}
+	fxPositions = newFXPositions(10000)
+)
+
+type fxPosition struct {
+	currency int
+	amount   fixed
+}
+
+func newFXPositions(n int) []fxPosition {
+	random := rand.New(rand.NewPCG(1, 1))
+	positions := make([]fxPosition, n)
+	for i := range positions {
+		positions[i].currency = random.IntN(len(fxCurrencies))
+		positions[i].amount = fixed{random.Int64N(10_000_000_000) - 2_000_000_000, 2}
+	}
+	return positions
+}
+
+func fxGV() (string, error) {
+	rates := make([]gv.Decimal, len(fxRates))
+	for i, r := range fxRates {
+		rates[i] = gv.MustNew(r.coef, int(r.scale))
+	}
+	sums := make([]gv.Decimal, len(fxCurrencies))
+	total := gv.Zero
+	for _, p := range fxPositions {
+		amount, err := gv.New(p.amount.coef, int(p.amount.scale))
+		if err != nil {
+			return "", err
+		}
+		value, err := amount.Mul(rates[p.currency])
+		if err != nil {
+			return "", err
+		}
+		value = value.Round(2)
+		sums[p.currency], err = sums[p.currency].Add(value)
+		if err != nil {
+			return "", err
+		}
+		total, err = total.Add(value)
+		if err != nil {
+			return "", err
+		}
+	}
+	parts := make([]string, 0, len(sums)+1)
+	for i, s := range sums {
+		parts = append(parts, fmt.Sprintf("%v=%v", fxCurrencies[i], fixedGV(s, 2)))
+	}
+	parts = append(parts, fmt.Sprintf("total=%v", fixedGV(total, 2)))
+	return strings.Join(parts, " "), nil
+}
+
+func fxCD() (string, error) {
+	ctx := cd.BaseContext
+	ctx.Precision = 19
+	ctx.Rounding = cd.RoundHalfEven
+	rates := make([]*cd.Decimal, len(fxRates))
+	for i, r := range fxRates {
+		rates[i] = cd.New(r.coef, -r.scale)
+	}
+	sums := make([]*cd.Decimal, len(fxCurrencies))
+	for i := range sums {
+		sums[i] = cd.New(0, 0)
+	}
+	total := cd.New(0, 0)
+	for _, p := range fxPositions {
+		amount := cd.New(p.amount.coef, -p.amount.scale)
+		value := new(cd.Decimal)
+		_, err := ctx.Mul(value, amount, rates[p.currency])
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Quantize(value, value, -2)
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Add(sums[p.currency], sums[p.currency], value)
+		if err != nil {
+			return "", err
+		}
+		_, err = ctx.Add(total, total, value)
+		if err != nil {
+			return "", err
+		}
+	}
+	pairs := make([]any, 0, 2*len(sums)+2)
+	for i, s := range sums {
+		pairs = append(pairs, fxCurrencies[i], s)
+	}
+	pairs = append(pairs, "total", total)
+	return fixedTotalsCD(2, pairs...)
+}
+
+func fxSS() (string, error) {
+	rates := make([]ss.Decimal, len(fxRates))
+	for i, r := range fxRates {
+		rates[i] = ss.New(r.coef, -r.scale)
+	}
+	sums := make([]ss.Decimal, len(fxCurrencies))
+	total := ss.Zero
+	for _, p := range fxPositions {
+		amount := ss.New(p.amount.coef, -p.amount.scale)
+		value := amount.Mul(rates[p.currency]).RoundBank(2)
+		sums[p.currency] = sums[p.currency].Add(value)
+		total = total.Add(value)
+	}
+	parts := make([]string, 0, len(sums)+1)
+	for i, s := range sums {
+		parts = append(parts, fmt.Sprintf("%v=%v", fxCurrencies[i], fixedSS(s, 2)))
+	}
+	parts = append(parts, fmt.Sprintf("total=%v", fixedSS(total, 2)))
+	return strings.Join(parts, " "), nil
+}
+
+// Order book matching.
+// Incoming buy orders are matched against ask price levels, starting from
+// the best price, while the level price does not exceed the order limit.
+// Every fill reduces the quantities of the order and of the level,
+// and adds its notional value to the total.
+var (
+	bookAsks   = newBookLevels(200, 10000, 250)
+	bookOrders = newBookOrders(1000)
+)
+
+type bookLevel struct {
+	price    fixed
+	quantity fixed
+}
+
+func newBookLevels(n int, price, quantity int64) []bookLevel {
+	levels := make([]bookLevel, n)
+	for i := range levels {
+		levels[i].price = fixed{price + int64(i), 2}
+		levels[i].quantity = fixed{quantity * int64(i*7%13+1), 3}
+	}
+	return levels
+}
+
+func newBookOrders(n int) []bookLevel {
+	orders := make([]bookLevel, n)
+	for i := range orders {
+		orders[i].price = fixed{10000 + int64(i*17%150), 2}
+		orders[i].quantity = fixed{100 * int64(i*5%11+1), 3}
+	}
+	return orders
+}
+
+func orderBookGV() (string, error) {
+	prices := make([]gv.Decimal, len(bookAsks))
+	quantities := make([]gv.Decimal, len(bookAsks))
+	for i, l := range bookAsks {
+		prices[i] = gv.MustNew(l.price.coef, int(l.price.scale))
+		quantities[i] = gv.MustNew(l.quantity.coef, int(l.quantity.scale))
+	}
+	filled := gv.Zero
+	notional := gv.Zero
+	unfilled := gv.Zero
+	top := 0
+	for _, o := range bookOrders {
+		limit, err := gv.New(o.price.coef, int(o.price.scale))
+		if err != nil {
+			return "", err
+		}
+		remaining, err := gv.New(o.quantity.coef, int(o.quantity.scale))
+		if err != nil {
+			return "", err
+		}
+		for top < len(prices) && remaining.IsPos() && prices[top].Cmp(limit) <= 0 {
+			fill := remaining
+			if quantities[top].Cmp(fill) < 0 {
+				fill = quantities[top]
+			}
+			remaining, err = remaining.Sub(fill)
+			if err != nil {
+				return "", err
+			}
+			quantities[top], err = quantities[top].Sub(fill)
+			if err != nil {
+				return "", err
+			}
+			filled, err = filled.Add(fill)
+			if err != nil {
+				return "", err
+			}
+			value, err := fill.Mul(prices[top])
+			if err != nil {
+				return "", err
+			}
+			notional, err = notional.Add(value)
+			if err != nil {
+				return "", err
+			}
+			if quantities[top].IsZero() {
+				top++
+			}
+		}
+		unfilled, err = unfilled.Add(remaining)
+		if err != nil {
+			return "", err
+		}
+	}
+	return fmt.Sprintf("filled=%v notional=%v unfilled=%v levels=%v",
+		fixedGV(filled, 3), fixedGV(notional, 5), fixedGV(unfilled, 3), top), nil
+}
+
+func orderBookCD() (string, error) {
+	ctx := cd.BaseContext
+	ctx.Precision = 19
+	ctx.Rounding = cd.RoundHalfEven
+	prices := make([]*cd.Decimal, len(bookAsks))
+	quantities := make([]*cd.Decimal, len(bookAsks))
+	for i, l := range bookAsks {
+		prices[i] = cd.New(l.price.coef, -l.price.scale)
+		quantities[i] = cd.New(l.quantity.coef, -l.quantity.scale)
+	}
+	filled := cd.New(0, 0)
+	notional := cd.New(0, 0)
+	unfilled := cd.New(0, 0)
+	fill := new(cd.Decimal)
+	value := new(cd.Decimal)
+	top := 0
+	for _, o := range bookOrders {
+		limit := cd.New(o.price.coef, -o.price.scale)
+		remaining := cd.New(o.quantity.coef, -o.quantity.scale)
+		for top < len(prices) && remaining.Sign() > 0 && prices[top].Cmp(limit) <= 0 {
+			if remaining.Cmp(quantities[top]) < 0 {
+				fill.Set(remaining)
+			} else {
+				fill.Set(quantities[top])
+			}
+			_, err := ctx.Sub(remaining, remaining, fill)
+			if err != nil {
+				return "", err
+			}
+			_, err = ctx.Sub(quantities[top], quantities[top], fill)
+			if err != nil {
+				return "", err
+			}
+			_, err = ctx.Add(filled, filled, fill)
+			if err != nil {
+				return "", err
+			}
+			_, err = ctx.Mul(value, fill, prices[top])
+			if err != nil {
+				return "", err
+			}
+			_, err = ctx.Add(notional, notional, value)
+			if err != nil {
+				return "", err
+			}
+			if quantities[top].IsZero() {
+				top++
+			}
+		}
+		_, err := ctx.Add(unfilled, unfilled, remaining)
+		if err != nil {
+			return "", err
+		}
+	}
+	q, err := fixedTotalsCD(3, "filled", filled)
+	if err != nil {
+		return "", err
+	}
+	n, err := fixedTotalsCD(5, "notional", notional)
+	if err != nil {
+		return "", err
+	}
+	u, err := fixedTotalsCD(3, "unfilled", unfilled)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v %v %v levels=%v", q, n, u, top), nil
+}
+
+func orderBookSS() (string, error) {
+	prices := make([]ss.Decimal, len(bookAsks))
+	quantities := make([]ss.Decimal, len(bookAsks))
+	for i, l := range bookAsks {
+		prices[i] = ss.New(l.price.coef, -l.price.scale)
+		quantities[i] = ss.New(l.quantity.coef, -l.quantity.scale)
+	}
+	filled := ss.Zero
+	notional := ss.Zero
+	unfilled := ss.Zero
+	top := 0
+	for _, o := range bookOrders {
+		limit := ss.New(o.price.coef, -o.price.scale)
+		remaining := ss.New(o.quantity.coef, -o.quantity.scale)
+		for top < len(prices) && remaining.IsPositive() && prices[top].Cmp(limit) <= 0 {
+			fill := ss.Min(remaining, quantities[top])
+			remaining = remaining.Sub(fill)
+			quantities[top] = quantities[top].Sub(fill)
+			filled = filled.Add(fill)
+			notional = notional.Add(fill.Mul(prices[top]))
+			if quantities[top].IsZero() {
+				top++
+			}
+		}
+		unfilled = unfilled.Add(remaining)
+	}
+	return fmt.Sprintf("filled=%v notional=%v unfilled=%v levels=%v",
+		fixedSS(filled, 3), fixedSS(notional, 5), fixedSS(unfilled, 3), top), nil
+}
@@ -0,0 +1,332 @@
+// Benchgate compares benchmark results with a committed baseline and fails
+// if a govalues benchmark got slower or allocates more.
+//
+// Results are compared like in benchstat. For every govalues benchmark and
+// unit, the medians of the baseline and current samples are compared, and
+// the difference is significant if the p-value of the Mann-Whitney U-test
+// is below -alpha. Samples where all values are equal, such as allocations
+// measured by a single run, are compared exactly and have no p-value.
+// A regression is a significant change in the worse direction
+// by more than -threshold percent.
+// If an allocation-free benchmark starts allocating, the change is infinite.
+//
+// Benchgate writes a markdown table with all comparisons, lists
+// the regressions, and exits with status 1 if there are any.
+//
+// Usage:
+//
+//	go -C cmd run ./benchgate [flags] ../bench/benchcpu.txt ../bench/benchmem.txt
+//
+// The baseline is replaced with the govalues results of the files with:
+//
+//	go -C cmd run ./benchgate -update ../bench/benchcpu.txt ../bench/benchmem.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"strings"
+
+	"golang.org/x/perf/benchfmt"
+	"golang.org/x/perf/benchmath"
+	"golang.org/x/perf/benchunit"
+)
+
+const (
+	modKey  = "/mod=govalues"
+	cfgSep  = ": "
+	benchID = "Benchmark"
+)
+
+func main() {
+	baseline := flag.String("baseline", "../bench/baseline.txt", "baseline file")
+	threshold := flag.Float64("threshold", 5, "maximum allowed change in the worse direction, in percent")
+	alpha := flag.Float64("alpha", 0.05, "significance level of the U-test")
+	summary := flag.String("summary", "", "markdown summary file (default standard output)")
+	update := flag.Bool("update", false, "replace the baseline with the results of the files")
+	flag.Parse()
+
+	files := flag.Args()
+	if len(files) == 0 {
+		log.Fatal("no benchmark files")
+	}
+
+	if *update {
+		var buf bytes.Buffer
+		for _, name := range files {
+			data, err := os.ReadFile(name)
+			if err != nil {
+				log.Fatalf("ReadFile(%q) failed: %v", name, err)
+			}
+			buf.Write(filterGovalues(data))
+		}
+		err := os.WriteFile(*baseline, buf.Bytes(), 0o600)
+		if err != nil {
+			log.Fatalf("WriteFile(%q) failed: %v", *baseline, err)
+		}
+		return
+	}
+
+	old, err := readSamples(*baseline)
+	if err != nil {
+		log.Fatal(err)
+	}
+	cur, err := readSamples(files...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	rows := compare(old, cur, *alpha, *threshold)
+
+	var buf bytes.Buffer
+	writeMarkdown(&buf, rows, *threshold)
+	if *summary == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+	} else {
+		err = os.WriteFile(*summary, buf.Bytes(), 0o600)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var failed bool
+	for _, r := range rows {
+		if r.regression {
+			log.Printf("regression: %v %v %v", r.name, r.unit, r.delta())
+			failed = true
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+// filterGovalues returns the configuration lines and the results
+// of govalues benchmarks.
+func filterGovalues(data []byte) []byte {
+	var buf bytes.Buffer
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := s.Text()
+		if (strings.HasPrefix(line, benchID) && isGovalues(benchfmt.Name(benchName(line)))) || isConfig(line) {
+			buf.WriteString(line)
+			buf.WriteByte('\n')
+		}
+	}
+	return buf.Bytes()
+}
+
+// benchName returns the first field of a benchmark line.
+func benchName(line string) string {
+	name, _, _ := strings.Cut(line, "\t")
+	return strings.TrimSpace(name)
+}
+
+// isConfig reports whether the line is a configuration line,
+// such as "goos: linux".
+func isConfig(line string) bool {
+	key, _, ok := strings.Cut(line, cfgSep)
+	if !ok || key == "" || key[0] < 'a' || key[0] > 'z' {
+		return false
+	}
+	return !strings.ContainsAny(key, " \t")
+}
+
+// isGovalues reports whether the benchmark measures govalues.
+func isGovalues(name benchfmt.Name) bool {
+	_, parts := name.Parts()
+	for _, p := range parts {
+		if string(p) == modKey {
+			return true
+		}
+	}
+	return false
+}
+
+// key identifies a sample.
+// The name has no GOMAXPROCS suffix, so that results from machines
+// with a different number of CPUs can be compared.
+type key struct {
+	name, unit string
+}
+
+func newKey(name benchfmt.Name, unit string) key {
+	base, parts := name.Parts()
+	var b strings.Builder
+	b.Write(base)
+	for _, p := range parts {
+		if p[0] != '-' {
+			b.Write(p)
+		}
+	}
+	return key{b.String(), unit}
+}
+
+// samples are the values of govalues benchmarks in the order
+// of their first appearance.
+type samples struct {
+	keys   []key
+	values map[key][]float64
+	units  benchfmt.UnitMetadataMap
+}
+
+func newSamples() *samples {
+	return &samples{
+		values: make(map[key][]float64),
+		units:  make(benchfmt.UnitMetadataMap),
+	}
+}
+
+func readSamples(names ...string) (*samples, error) {
+	s := newSamples()
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return nil, err
+		}
+		err = s.read(f, name)
+		f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+func (s *samples) read(r io.Reader, name string) error {
+	br := benchfmt.NewReader(r, name)
+	for br.Scan() {
+		switch rec := br.Result().(type) {
+		case *benchfmt.SyntaxError:
+			return rec
+		case *benchfmt.Result:
+			if !isGovalues(rec.Name) {
+				continue
+			}
+			for _, v := range rec.Values {
+				k := newKey(rec.Name, v.Unit)
+				if _, ok := s.values[k]; !ok {
+					s.keys = append(s.keys, k)
+				}
+				s.values[k] = append(s.values[k], v.Value)
+			}
+		}
+	}
+	for k, v := range br.Units() {
+		s.units[k] = v
+	}
+	return br.Err()
+}
+
+// row is a comparison of the baseline and current samples.
+type row struct {
+	name, unit string
+	old, new   benchmath.Summary
+	cmp        benchmath.Comparison
+	exact      bool
+	regression bool
+}
+
+// delta returns the change in the center of the samples, like benchstat.
+func (r row) delta() string {
+	if r.old.Center == 0 && r.new.Center != 0 && r.cmp.P <= r.cmp.Alpha {
+		return "+∞"
+	}
+	return r.cmp.FormatDelta(r.old.Center, r.new.Center)
+}
+
+// compare compares the samples present in both the baseline and the current
+// results. Units without a known better direction are compared but never
+// reported as regressions.
+func compare(old, cur *samples, alpha, threshold float64) []row {
+	thresholds := benchmath.DefaultThresholds
+	thresholds.CompareAlpha = alpha
+	var rows []row
+	for _, k := range cur.keys {
+		ov, ok := old.values[k]
+		if !ok {
+			continue
+		}
+		s1 := benchmath.NewSample(ov, &thresholds)
+		s2 := benchmath.NewSample(cur.values[k], &thresholds)
+		assumption := cur.units.GetAssumption(k.unit)
+		exact := isConstant(s1) && isConstant(s2)
+		if exact {
+			assumption = benchmath.AssumeExact
+		}
+		r := row{
+			name:  strings.TrimPrefix(k.name, benchID),
+			unit:  k.unit,
+			old:   assumption.Summary(s1, 0.95),
+			new:   assumption.Summary(s2, 0.95),
+			cmp:   assumption.Compare(s1, s2),
+			exact: exact,
+		}
+		r.cmp.Alpha = alpha
+		if better := cur.units.GetBetter(k.unit); better != 0 && r.cmp.P <= alpha {
+			change := percent(r.old.Center, r.new.Center)
+			r.regression = -float64(better)*change > threshold
+		}
+		rows = append(rows, r)
+	}
+	return rows
+}
+
+// pValue returns the p-value of the U-test, or "exact" if the samples
+// were compared exactly.
+func (r row) pValue() string {
+	if r.exact {
+		return "exact"
+	}
+	return fmt.Sprintf("%.3f", r.cmp.P)
+}
+
+// samples returns the sizes of the baseline and current samples.
+func (r row) samples() string {
+	if r.cmp.N1 == r.cmp.N2 {
+		return fmt.Sprintf("%v", r.cmp.N1)
+	}
+	return fmt.Sprintf("%v+%v", r.cmp.N1, r.cmp.N2)
+}
+
+func isConstant(s *benchmath.Sample) bool {
+	return s.Values[0] == s.Values[len(s.Values)-1]
+}
+
+// percent returns the relative change from old to new.
+func percent(old, new float64) float64 {
+	switch {
+	case old == new:
+		return 0
+	case old == 0:
+		return math.Copysign(math.Inf(1), new)
+	default:
+		return (new/old - 1) * 100
+	}
+}
+
+// writeMarkdown writes the comparisons as a markdown table.
+func writeMarkdown(buf *bytes.Buffer, rows []row, threshold float64) {
+	var regressions int
+	buf.WriteString("| Benchmark | Unit | Baseline | Current | Delta | P-value | Samples | Regression |\n")
+	buf.WriteString("|:--|:--|--:|--:|--:|--:|--:|:-:|\n")
+	for _, r := range rows {
+		cls := benchunit.ClassOf(r.unit)
+		mark := ""
+		if r.regression {
+			mark = "yes"
+			regressions++
+		}
+		fmt.Fprintf(buf, "| %v | %v | %v ± %v | %v ± %v | %v | %v | %v | %v |\n",
+			r.name, r.unit,
+			benchunit.Scale(r.old.Center, cls), r.old.PctRangeString(),
+			benchunit.Scale(r.new.Center, cls), r.new.PctRangeString(),
+			r.delta(), r.pValue(), r.samples(), mark)
+	}
+	fmt.Fprintf(buf, "\n%v comparisons, %v regressions above %v%%.\n", len(rows), regressions, threshold)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFilterGovalues(t *testing.T) {
+	data := []byte(`goos: linux
+goarch: amd64
+pkg: github.com/govalues/decimal-tests/bench
+BenchmarkDecimal_Add/size=small/mod=govalues-8     	46922866	        26.29 ns/op
+BenchmarkDecimal_Add/size=small/mod=cockroachdb-8  	12924774	       102.7 ns/op
+BenchmarkDecimal_Telco/mod=govalues-8              	 1000000	       112.0 ns/op	       0 B/op	       0 allocs/op
+BenchmarkDecimal_Telco/mod=govaluesx-8             	 1000000	       112.0 ns/op
+PASS
+ok  	github.com/govalues/decimal-tests/bench	10.5s
+`)
+	want := `goos: linux
+goarch: amd64
+pkg: github.com/govalues/decimal-tests/bench
+BenchmarkDecimal_Add/size=small/mod=govalues-8     	46922866	        26.29 ns/op
+BenchmarkDecimal_Telco/mod=govalues-8              	 1000000	       112.0 ns/op	       0 B/op	       0 allocs/op
+`
+	got := string(filterGovalues(data))
+	if got != want {
+		t.Errorf("filterGovalues() = %q, want %q", got, want)
+	}
+}
+
+// results returns a benchmark output with a govalues result for every value.
+func results(name, unit string, values ...float64) string {
+	var b strings.Builder
+	for _, v := range values {
+		fmt.Fprintf(&b, "Benchmark%v/mod=govalues-8\t1000\t%v %v\n", name, v, unit)
+	}
+	return b.String()
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name           string
+		old, cur       string
+		wantRegression bool
+		wantDelta      string
+	}{
+		{
+			"slower",
+			results("Add", "ns/op", 10, 10.1, 9.9, 10, 10.2, 9.8),
+			results("Add", "ns/op", 12, 12.1, 11.9, 12, 12.2, 11.8),
+			true,
+			"+20.00%",
+		},
+		{
+			"faster",
+			results("Add", "ns/op", 12, 12.1, 11.9, 12, 12.2, 11.8),
+			results("Add", "ns/op", 10, 10.1, 9.9, 10, 10.2, 9.8),
+			false,
+			"-16.67%",
+		},
+		{
+			"below threshold",
+			results("Add", "ns/op", 10, 10.01, 9.99, 10, 10.02, 9.98),
+			results("Add", "ns/op", 10.3, 10.31, 10.29, 10.3, 10.32, 10.28),
+			false,
+			"+3.00%",
+		},
+		{
+			"noise",
+			results("Add", "ns/op", 10, 14, 9, 12, 10, 11),
+			results("Add", "ns/op", 11, 9, 13, 10, 12, 10),
+			false,
+			"~",
+		},
+		{
+			"too few samples",
+			results("Add", "ns/op", 10, 10.1),
+			results("Add", "ns/op", 20, 20.1),
+			false,
+			"~",
+		},
+		{
+			"more bytes",
+			results("Add", "B/op", 16),
+			results("Add", "B/op", 24),
+			true,
+			"+50.00%",
+		},
+		{
+			"new allocation",
+			results("Add", "allocs/op", 0),
+			results("Add", "allocs/op", 1),
+			true,
+			"+∞",
+		},
+		{
+			"same allocations",
+			results("Add", "allocs/op", 0, 0, 0),
+			results("Add", "allocs/op", 0),
+			false,
+			"0.00%",
+		},
+		{
+			"unknown unit",
+			results("Add", "widgets/op", 1),
+			results("Add", "widgets/op", 2),
+			false,
+			"+100.00%",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := mustSamples(t, tt.old)
+			cur := mustSamples(t, tt.cur)
+			rows := compare(old, cur, 0.05, 5)
+			if len(rows) != 1 {
+				t.Fatalf("compare() returned %v rows, want 1", len(rows))
+			}
+			if rows[0].regression != tt.wantRegression {
+				t.Errorf("compare() regression = %v, want %v", rows[0].regression, tt.wantRegression)
+			}
+			if got := rows[0].delta(); got != tt.wantDelta {
+				t.Errorf("delta() = %q, want %q", got, tt.wantDelta)
+			}
+		})
+	}
+}
+
+func TestCompare_names(t *testing.T) {
+	// Different GOMAXPROCS, other libraries, and missing baselines
+	old := mustSamples(t, `BenchmarkAdd/mod=govalues-8	1000	10 ns/op
+BenchmarkAdd/mod=shopspring-8	1000	10 ns/op
+`)
+	cur := mustSamples(t, `BenchmarkAdd/mod=govalues-4	1000	10 ns/op
+BenchmarkAdd/mod=shopspring-4	1000	30 ns/op
+BenchmarkMul/mod=govalues-4	1000	30 ns/op
+`)
+	rows := compare(old, cur, 0.05, 5)
+	if len(rows) != 1 {
+		t.Fatalf("compare() returned %v rows, want 1", len(rows))
+	}
+	if got, want := rows[0].name, "Add/mod=govalues"; got != want {
+		t.Errorf("compare() name = %q, want %q", got, want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	old := mustSamples(t, results("Add", "allocs/op", 0))
+	cur := mustSamples(t, results("Add", "allocs/op", 1))
+	var buf bytes.Buffer
+	writeMarkdown(&buf, compare(old, cur, 0.05, 5), 5)
+	want := "| Add/mod=govalues | allocs/op | 0.000 ± 0% | 1.000 ± 0% | +∞ | exact | 1 | yes |\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("writeMarkdown() = %q, want it to contain %q", buf.String(), want)
+	}
+
+	old = mustSamples(t, results("Add", "ns/op", 10, 11, 10, 11, 10, 11))
+	cur = mustSamples(t, results("Add", "ns/op", 20, 21, 20, 21, 20, 21))
+	buf.Reset()
+	writeMarkdown(&buf, compare(old, cur, 0.05, 5), 5)
+	want = "| +95.24% | 0.002 | 6 | yes |\n"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("writeMarkdown() = %q, want it to contain %q", buf.String(), want)
+	}
+	if want := "1 comparisons, 1 regressions above 5%."; !strings.Contains(buf.String(), want) {
+		t.Errorf("writeMarkdown() = %q, want it to contain %q", buf.String(), want)
+	}
+}
+
+func mustSamples(t *testing.T, data string) *samples {
+	t.Helper()
+	s := newSamples()
+	err := s.read(strings.NewReader(data), "test")
+	if err != nil {
+		t.Fatalf("read() failed: %v", err)
+	}
+	return s
+}
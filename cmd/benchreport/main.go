@@ -0,0 +1,387 @@
+// Benchreport renders benchmark results as comparison tables
+// in markdown and CSV.
+//
+// For every benchmark and input, such as size=money, the tables show
+// the median time and allocated memory of every library, and their ratios
+// to govalues. A ratio above 1 means that the library is slower than
+// govalues or allocates more.
+// The tables start with the platform, CPU, and Go version, so that
+// published comparisons can be reproduced.
+// The Go version is read from the "go:" configuration line, which
+// "task bench" records, and is reported as unknown if the line is missing.
+//
+// Usage:
+//
+//	go -C cmd run ./benchreport [flags] ../bench/benchcpu.txt ../bench/benchmem.txt
+//
+// With -run, benchreport runs the benchmarks itself instead of reading files:
+//
+//	go -C cmd run ./benchreport -run -bench Decimal_Add -count 10
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"os"
+	"os/exec"
+	"strings"
+
+	"golang.org/x/perf/benchfmt"
+	"golang.org/x/perf/benchmath"
+	"golang.org/x/perf/benchunit"
+)
+
+const (
+	modKey  = "/mod="
+	baseMod = "govalues"
+	benchID = "Benchmark"
+)
+
+// units are the units of the tables, as tidied by benchfmt.
+var units = []string{"sec/op", "B/op"}
+
+// metaKeys are the configuration keys of the metadata.
+var metaKeys = []string{"goos", "goarch", "cpu", "go"}
+
+func main() {
+	run := flag.Bool("run", false, "run the benchmarks instead of reading files")
+	bench := flag.String("bench", ".", "run only benchmarks matching the regular expression")
+	count := flag.Int("count", 10, "run each benchmark n times")
+	benchtime := flag.String("benchtime", "", "run each benchmark for the specified duration")
+	timeout := flag.String("timeout", "120m", "panic test binary after the specified duration")
+	pkg := flag.String("pkg", "../bench", "directory of the package with benchmarks")
+	mdFile := flag.String("md", "../bench/report.md", "markdown output file")
+	csvFile := flag.String("csv", "../bench/report.csv", "CSV output file")
+	flag.Parse()
+
+	var inputs []input
+	if *run {
+		out, err := runBench(*pkg, *bench, *count, *benchtime, *timeout)
+		if err != nil {
+			log.Fatalf("running benchmarks failed: %v", err)
+		}
+		inputs = append(inputs, input{"go test", out})
+	} else {
+		files := flag.Args()
+		if len(files) == 0 {
+			files = []string{"../bench/benchcpu.txt", "../bench/benchmem.txt"}
+		}
+		for _, name := range files {
+			data, err := os.ReadFile(name)
+			if err != nil {
+				log.Fatalf("ReadFile(%q) failed: %v", name, err)
+			}
+			inputs = append(inputs, input{name, data})
+		}
+	}
+
+	r, err := readReport(inputs...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var md bytes.Buffer
+	r.writeMarkdown(&md)
+	err = os.WriteFile(*mdFile, md.Bytes(), 0o600)
+	if err != nil {
+		log.Fatalf("WriteFile(%q) failed: %v", *mdFile, err)
+	}
+
+	var buf bytes.Buffer
+	err = r.writeCSV(&buf)
+	if err != nil {
+		log.Fatal(err)
+	}
+	err = os.WriteFile(*csvFile, buf.Bytes(), 0o600)
+	if err != nil {
+		log.Fatalf("WriteFile(%q) failed: %v", *csvFile, err)
+	}
+}
+
+// runBench runs the benchmarks in the package directory with the memory
+// statistics and returns the output of "go test".
+// The Go version of the toolchain that runs the benchmarks is added
+// as a configuration line.
+func runBench(pkg, bench string, count int, benchtime, timeout string) ([]byte, error) {
+	args := []string{"test", "-run=^$", "-bench=" + bench, "-benchmem", fmt.Sprintf("-count=%v", count)}
+	if benchtime != "" {
+		args = append(args, "-benchtime="+benchtime)
+	}
+	if timeout != "" {
+		args = append(args, "-timeout="+timeout)
+	}
+	args = append(args, ".")
+	log.Printf("go %v (%v)", strings.Join(args, " "), pkg)
+	env := exec.Command("go", "env", "GOVERSION")
+	env.Dir = pkg
+	ver, err := env.Output()
+	if err != nil {
+		return nil, fmt.Errorf("go env GOVERSION: %w", err)
+	}
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "go: %v\n", strings.TrimSpace(string(ver)))
+	test := exec.Command("go", args...)
+	test.Dir = pkg
+	test.Stdout = io.MultiWriter(&out, os.Stdout)
+	test.Stderr = os.Stderr
+	err = test.Run()
+	if err != nil {
+		return nil, fmt.Errorf("go test: %w", err)
+	}
+	return out.Bytes(), nil
+}
+
+// input is a benchmark output.
+type input struct {
+	name string
+	data []byte
+}
+
+// row is a benchmark with a specific input, such as
+// Decimal_Add with size=money.
+type row struct {
+	bench, input string
+}
+
+type cell struct {
+	row       row
+	mod, unit string
+}
+
+// report holds the samples of every library for every row and unit.
+type report struct {
+	meta   map[string]string
+	rows   []row
+	mods   []string
+	values map[cell][]float64
+}
+
+func readReport(inputs ...input) (*report, error) {
+	r := &report{
+		meta:   make(map[string]string),
+		values: make(map[cell][]float64),
+	}
+	seenRows := make(map[row]bool)
+	seenMods := map[string]bool{baseMod: true}
+	r.mods = []string{baseMod}
+	for _, in := range inputs {
+		br := benchfmt.NewReader(bytes.NewReader(in.data), in.name)
+		for br.Scan() {
+			switch rec := br.Result().(type) {
+			case *benchfmt.SyntaxError:
+				return nil, rec
+			case *benchfmt.Result:
+				for _, key := range metaKeys {
+					if v := rec.GetConfig(key); v != "" {
+						r.meta[key] = v
+					}
+				}
+				rw, mod, ok := split(rec.Name)
+				if !ok {
+					continue
+				}
+				if !seenRows[rw] {
+					seenRows[rw] = true
+					r.rows = append(r.rows, rw)
+				}
+				if !seenMods[mod] {
+					seenMods[mod] = true
+					r.mods = append(r.mods, mod)
+				}
+				for _, v := range rec.Values {
+					c := cell{rw, mod, v.Unit}
+					r.values[c] = append(r.values[c], v.Value)
+				}
+			}
+		}
+		if err := br.Err(); err != nil {
+			return nil, err
+		}
+	}
+	if r.meta["go"] == "" {
+		r.meta["go"] = "unknown"
+	}
+	return r, nil
+}
+
+// split splits a benchmark name into the row and the library.
+// It returns false if the benchmark has no /mod= key.
+func split(name benchfmt.Name) (row, string, bool) {
+	base, parts := name.Parts()
+	var mod string
+	var in []string
+	for _, p := range parts {
+		switch {
+		case p[0] == '-':
+			// GOMAXPROCS
+		case bytes.HasPrefix(p, []byte(modKey)):
+			mod = string(p[len(modKey):])
+		default:
+			in = append(in, string(p[1:]))
+		}
+	}
+	if mod == "" {
+		return row{}, "", false
+	}
+	return row{strings.TrimPrefix(string(base), benchID), strings.Join(in, "/")}, mod, true
+}
+
+// median returns the median of the cell, or false if there are no samples.
+func (r *report) median(c cell) (float64, bool) {
+	values := r.values[c]
+	if len(values) == 0 {
+		return 0, false
+	}
+	s := benchmath.NewSample(values, &benchmath.DefaultThresholds)
+	return benchmath.AssumeNothing.Summary(s, 0.95).Center, true
+}
+
+// ratio returns the ratio of the cell to the govalues cell of the same row.
+func (r *report) ratio(c cell) (float64, bool) {
+	v, ok := r.median(c)
+	if !ok {
+		return 0, false
+	}
+	base := c
+	base.mod = baseMod
+	b, ok := r.median(base)
+	if !ok || b == 0 {
+		return 0, false
+	}
+	return v / b, true
+}
+
+// hasUnit reports whether any benchmark has the unit.
+func (r *report) hasUnit(unit string) bool {
+	for c := range r.values {
+		if c.unit == unit {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *report) writeMarkdown(buf *bytes.Buffer) {
+	for _, key := range metaKeys {
+		if v := r.meta[key]; v != "" {
+			fmt.Fprintf(buf, "- %v: %v\n", key, v)
+		}
+	}
+	buf.WriteString("\nMedians of all runs. Ratios to govalues are in parentheses.\n")
+	for _, unit := range units {
+		if !r.hasUnit(unit) {
+			continue
+		}
+		fmt.Fprintf(buf, "\n### %v\n\n", title(unit))
+		buf.WriteString("| Benchmark | Input |")
+		for _, mod := range r.mods {
+			fmt.Fprintf(buf, " %v |", mod)
+		}
+		buf.WriteString("\n|:--|:--|")
+		for range r.mods {
+			buf.WriteString("--:|")
+		}
+		buf.WriteByte('\n')
+		for _, rw := range r.rows {
+			if _, ok := r.median(cell{rw, baseMod, unit}); !ok {
+				continue
+			}
+			in := rw.input
+			if in == "" {
+				in = "-"
+			}
+			fmt.Fprintf(buf, "| %v | %v |", rw.bench, in)
+			for _, mod := range r.mods {
+				c := cell{rw, mod, unit}
+				v, ok := r.median(c)
+				switch {
+				case !ok:
+					buf.WriteString(" |")
+				case mod == baseMod:
+					fmt.Fprintf(buf, " %v |", format(v, unit))
+				default:
+					if x, ok := r.ratio(c); ok {
+						fmt.Fprintf(buf, " %v (%.2f×) |", format(v, unit), x)
+					} else {
+						fmt.Fprintf(buf, " %v |", format(v, unit))
+					}
+				}
+			}
+			buf.WriteByte('\n')
+		}
+	}
+}
+
+// writeCSV writes a record for every benchmark, input, library, and unit.
+// Every record repeats the metadata, so that records from different
+// machines can be concatenated.
+func (r *report) writeCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"benchmark", "input", "library", "unit", "median", "ratio"}
+	header = append(header, metaKeys...)
+	if err := cw.Write(header); err != nil {
+		return err
+	}
+	for _, unit := range units {
+		for _, rw := range r.rows {
+			for _, mod := range r.mods {
+				c := cell{rw, mod, unit}
+				v, ok := r.median(c)
+				if !ok {
+					continue
+				}
+				ratio := ""
+				if x, ok := r.ratio(c); ok {
+					ratio = fmt.Sprintf("%.4f", x)
+				}
+				rec := []string{rw.bench, rw.input, mod, csvUnit(unit), fmt.Sprintf("%g", csvValue(v, unit)), ratio}
+				for _, key := range metaKeys {
+					rec = append(rec, r.meta[key])
+				}
+				if err := cw.Write(rec); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func title(unit string) string {
+	switch unit {
+	case "sec/op":
+		return "Time (ns/op)"
+	case "B/op":
+		return "Memory (B/op)"
+	}
+	return unit
+}
+
+// format formats a median like benchstat, with an SI or binary prefix.
+func format(v float64, unit string) string {
+	if unit == "sec/op" {
+		return benchunit.Scale(v, benchunit.Decimal) + "s"
+	}
+	return benchunit.Scale(v, benchunit.ClassOf(unit)) + "B"
+}
+
+// csvUnit and csvValue restore the units of "go test" output.
+func csvUnit(unit string) string {
+	if unit == "sec/op" {
+		return "ns/op"
+	}
+	return unit
+}
+
+func csvValue(v float64, unit string) float64 {
+	if unit == "sec/op" {
+		return math.Round(v*1e9*1000) / 1000
+	}
+	return v
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+)
+
+var testCPU = `go: go1.99.0
+goos: linux
+goarch: amd64
+pkg: github.com/govalues/decimal-tests/bench
+cpu: Test CPU
+BenchmarkDecimal_Add/size=small/mod=govalues-8     	46922866	        20.00 ns/op
+BenchmarkDecimal_Add/size=small/mod=govalues-8     	46922866	        30.00 ns/op
+BenchmarkDecimal_Add/size=small/mod=govalues-8     	46922866	        25.00 ns/op
+BenchmarkDecimal_Add/size=small/mod=shopspring-8   	12924774	       100.0 ns/op
+BenchmarkDecimal_Add/size=small/mod=cockroachdb-8  	12924774	       50.00 ns/op
+BenchmarkDecimal_Telco/mod=govalues-8              	 1000000	       200.0 ns/op
+BenchmarkDecimal_Telco/mod=float64-8               	 1000000	       100.0 ns/op
+BenchmarkTelcoOutput-8                             	 1000000	       100.0 ns/op
+PASS
+ok  	github.com/govalues/decimal-tests/bench	10.5s
+`
+
+var testMem = `goos: linux
+goarch: amd64
+pkg: github.com/govalues/decimal-tests/bench
+cpu: Test CPU
+BenchmarkDecimal_Add/size=small/mod=govalues-8     	46922866	        26.00 ns/op	       0 B/op	       0 allocs/op
+BenchmarkDecimal_Add/size=small/mod=shopspring-8   	12924774	       100.0 ns/op	      80 B/op	       2 allocs/op
+BenchmarkDecimal_Add/size=small/mod=cockroachdb-8  	12924774	       50.00 ns/op	      16 B/op	       1 allocs/op
+`
+
+func mustReport(t *testing.T) *report {
+	t.Helper()
+	r, err := readReport(input{"cpu", []byte(testCPU)}, input{"mem", []byte(testMem)})
+	if err != nil {
+		t.Fatalf("readReport() failed: %v", err)
+	}
+	return r
+}
+
+func TestReadReport(t *testing.T) {
+	r := mustReport(t)
+	if got, want := strings.Join(r.mods, ","), "govalues,shopspring,cockroachdb,float64"; got != want {
+		t.Errorf("readReport() mods = %q, want %q", got, want)
+	}
+	wantRows := []row{{"Decimal_Add", "size=small"}, {"Decimal_Telco", ""}}
+	if len(r.rows) != len(wantRows) {
+		t.Fatalf("readReport() returned %v rows, want %v", len(r.rows), len(wantRows))
+	}
+	for i, w := range wantRows {
+		if r.rows[i] != w {
+			t.Errorf("readReport() rows[%v] = %v, want %v", i, r.rows[i], w)
+		}
+	}
+	if got, want := r.meta["cpu"], "Test CPU"; got != want {
+		t.Errorf("readReport() cpu = %q, want %q", got, want)
+	}
+	if got, want := r.meta["go"], "go1.99.0"; got != want {
+		t.Errorf("readReport() go = %q, want %q", got, want)
+	}
+
+	// The median of all runs in both files
+	v, _ := r.median(cell{wantRows[0], "govalues", "sec/op"})
+	if got, want := v, 25.5e-9; math.Abs(got-want) > 1e-15 {
+		t.Errorf("median() = %v, want %v", got, want)
+	}
+	x, _ := r.ratio(cell{wantRows[0], "shopspring", "sec/op"})
+	if got, want := x, 100/25.5; math.Abs(got-want) > 1e-9 {
+		t.Errorf("ratio() = %v, want %v", got, want)
+	}
+	if _, ok := r.ratio(cell{wantRows[0], "shopspring", "B/op"}); ok {
+		t.Errorf("ratio() to zero bytes is ok, want not ok")
+	}
+}
+
+func TestReadReport_noVersion(t *testing.T) {
+	r, err := readReport(input{"mem", []byte(testMem)})
+	if err != nil {
+		t.Fatalf("readReport() failed: %v", err)
+	}
+	if got, want := r.meta["go"], "unknown"; got != want {
+		t.Errorf("readReport() go = %q, want %q", got, want)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	r := mustReport(t)
+	var buf bytes.Buffer
+	r.writeMarkdown(&buf)
+	got := buf.String()
+	for _, want := range []string{
+		"- cpu: Test CPU\n",
+		"| Benchmark | Input | govalues | shopspring | cockroachdb | float64 |\n",
+		"| Decimal_Add | size=small | 25.50ns | 100.0ns (3.92×) | 50.00ns (1.96×) | |\n",
+		"| Decimal_Telco | - | 200.0ns | | | 100.0ns (0.50×) |\n",
+		"| Decimal_Add | size=small | 0.000B | 80.00B | 16.00B | |\n",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("writeMarkdown() = %q, want it to contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "TelcoOutput") {
+		t.Errorf("writeMarkdown() = %q, want no benchmarks without /mod=", got)
+	}
+}
+
+func TestWriteCSV(t *testing.T) {
+	r := mustReport(t)
+	var buf bytes.Buffer
+	if err := r.writeCSV(&buf); err != nil {
+		t.Fatalf("writeCSV() failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if got, want := lines[0], "benchmark,input,library,unit,median,ratio,goos,goarch,cpu,go"; got != want {
+		t.Errorf("writeCSV() header = %q, want %q", got, want)
+	}
+	if got, want := len(lines), 1+5+3; got != want {
+		t.Errorf("writeCSV() returned %v lines, want %v", got, want)
+	}
+	if want := "Decimal_Add,size=small,shopspring,ns/op,100,3.9216,linux,amd64,Test CPU,"; !strings.HasPrefix(lines[2], want) {
+		t.Errorf("writeCSV() line = %q, want prefix %q", lines[2], want)
+	}
+	if want := "Decimal_Add,size=small,shopspring,B/op,80,,linux,amd64,Test CPU,"; !strings.Contains(buf.String(), want) {
+		t.Errorf("writeCSV() = %q, want it to contain %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,303 @@
+// Benchtrend runs the benchmarks against several versions of govalues/decimal
+// and records the results in a history file.
+//
+// For every version, benchtrend creates an alternate go.mod file that requires
+// that version, runs the benchmarks with the -modfile flag, and adds a
+// /ver= key to the names of govalues benchmarks.
+// Results for the versions being run replace the existing results for
+// these versions in the history file, results for other versions are kept.
+// The benchmarks are built with the benchtrend tag, which leaves out
+// benchmarks of methods that older versions do not have.
+// Only the govalues sub-benchmarks are run, since the other libraries
+// do not change between versions.
+//
+// Usage:
+//
+//	go -C cmd run ./benchtrend -versions v0.1.35,v0.1.36 [flags]
+//
+// The history file can be compared with:
+//
+//	benchstat -filter ".unit:ns/op" -col /ver bench/history.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	module  = "github.com/govalues/decimal"
+	modKey  = "/mod=govalues"
+	verKey  = "/ver="
+	cfgSep  = ": "
+	benchID = "Benchmark"
+
+	// buildTag excludes benchmarks that do not compile with older versions.
+	buildTag = "benchtrend"
+)
+
+func main() {
+	versions := flag.String("versions", "", "comma-separated list of govalues/decimal versions")
+	bench := flag.String("bench", ".", "run only top-level benchmarks matching the regular expression")
+	count := flag.Int("count", 10, "run each benchmark n times")
+	benchtime := flag.String("benchtime", "", "run each benchmark for the specified duration")
+	timeout := flag.String("timeout", "", "panic test binary after the specified duration")
+	pkg := flag.String("pkg", "../bench", "directory of the package with benchmarks")
+	history := flag.String("history", "../bench/history.txt", "history file to update")
+	flag.Parse()
+
+	if *versions == "" {
+		log.Fatal("-versions is required")
+	}
+	if strings.Contains(*bench, "/") {
+		log.Fatal("-bench must not select sub-benchmarks")
+	}
+
+	var runs []run
+	for _, ver := range strings.Split(*versions, ",") {
+		ver = strings.TrimSpace(ver)
+		out, err := runBench(ver, *pkg, *bench, *count, *benchtime, *timeout)
+		if err != nil {
+			log.Fatalf("running benchmarks for %v failed: %v", ver, err)
+		}
+		runs = append(runs, run{ver: ver, out: out})
+	}
+
+	old, err := os.ReadFile(*history)
+	if err != nil && !os.IsNotExist(err) {
+		log.Fatalf("ReadFile(%q) failed: %v", *history, err)
+	}
+	h := merge(parseHistory(old), runs)
+	err = os.WriteFile(*history, h.bytes(), 0o600)
+	if err != nil {
+		log.Fatalf("WriteFile(%q) failed: %v", *history, err)
+	}
+}
+
+// runBench runs the benchmarks in the package directory against
+// the specified version and returns the output of "go test".
+func runBench(ver, pkg, bench string, count int, benchtime, timeout string) ([]byte, error) {
+	root, err := moduleDir(pkg)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := os.MkdirTemp("", "benchtrend")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	// Alternate module file
+	modfile := filepath.Join(dir, "go.mod")
+	for _, name := range []string{"go.mod", "go.sum"} {
+		data, err := os.ReadFile(filepath.Join(root, name))
+		if err != nil {
+			return nil, err
+		}
+		err = os.WriteFile(filepath.Join(dir, name), data, 0o600)
+		if err != nil {
+			return nil, err
+		}
+	}
+	get := exec.Command("go", "get", "-modfile="+modfile, module+"@"+ver)
+	get.Dir = pkg
+	get.Stderr = os.Stderr
+	err = get.Run()
+	if err != nil {
+		return nil, fmt.Errorf("go get: %w", err)
+	}
+
+	// Benchmarks
+	var out bytes.Buffer
+	for _, pattern := range govaluesPatterns(bench) {
+		args := []string{"test", "-modfile=" + modfile, "-tags=" + buildTag, "-run=^$", "-bench=" + pattern, fmt.Sprintf("-count=%v", count)}
+		if benchtime != "" {
+			args = append(args, "-benchtime="+benchtime)
+		}
+		if timeout != "" {
+			args = append(args, "-timeout="+timeout)
+		}
+		args = append(args, ".")
+		log.Printf("go %v (%v, %v)", strings.Join(args, " "), pkg, ver)
+		test := exec.Command("go", args...)
+		test.Dir = pkg
+		test.Stdout = io.MultiWriter(&out, os.Stdout)
+		test.Stderr = os.Stderr
+		err = test.Run()
+		if err != nil {
+			return nil, fmt.Errorf("go test: %w", err)
+		}
+	}
+	return out.Bytes(), nil
+}
+
+// govaluesPatterns returns the -bench patterns that select the govalues
+// sub-benchmarks of the top-level benchmarks matching bench.
+// The mod= key is the second or the third element of a benchmark name,
+// and "go test" reports only the benchmarks with at least as many elements
+// as the pattern, so every position needs its own pattern.
+// Elements are parenthesized, because "go test" splits a pattern
+// at a top-level | before it splits it into elements.
+func govaluesPatterns(bench string) []string {
+	const (
+		govalues = "(^mod=govalues$)"
+		other    = "(^[^m]|^m[^o]|^mo[^d]|^mod[^=])" // not a mod= key
+	)
+	bench = "(" + bench + ")"
+	return []string{
+		bench + "/" + govalues,
+		bench + "/" + other + "/" + govalues,
+	}
+}
+
+// moduleDir returns the root directory of the module that contains
+// the package directory.
+func moduleDir(pkg string) (string, error) {
+	cmd := exec.Command("go", "env", "GOMOD")
+	cmd.Dir = pkg
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("go env GOMOD: %w", err)
+	}
+	gomod := strings.TrimSpace(string(out))
+	if gomod == "" || gomod == os.DevNull {
+		return "", fmt.Errorf("%v is not in a module", pkg)
+	}
+	return filepath.Dir(gomod), nil
+}
+
+// run is an output of "go test" for a single version.
+type run struct {
+	ver string
+	out []byte
+}
+
+// history is a benchmark output with results for several versions.
+type history struct {
+	config  []string
+	results []string
+}
+
+// parseHistory returns the results and the configuration lines of data.
+// Configuration lines repeated by several runs are kept once.
+func parseHistory(data []byte) history {
+	var h history
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := s.Text()
+		switch {
+		case strings.HasPrefix(line, benchID) && strings.Contains(line, "\t"):
+			h.results = append(h.results, line)
+		case isConfig(line) && !slices.Contains(h.config, line):
+			h.config = append(h.config, line)
+		}
+	}
+	return h
+}
+
+// isConfig reports whether the line is a configuration line,
+// such as "goos: linux".
+func isConfig(line string) bool {
+	key, _, ok := strings.Cut(line, cfgSep)
+	if !ok || key == "" || key[0] < 'a' || key[0] > 'z' {
+		return false
+	}
+	return !strings.ContainsAny(key, " \t")
+}
+
+// merge replaces the results for the versions of the runs.
+// Only govalues benchmarks are recorded, since the other libraries
+// do not change between runs.
+func merge(h history, runs []run) history {
+	replaced := make(map[string]bool, len(runs))
+	for _, r := range runs {
+		replaced[r.ver] = true
+	}
+
+	var m history
+	m.config = h.config
+	for _, line := range h.results {
+		if !replaced[version(line)] {
+			m.results = append(m.results, line)
+		}
+	}
+
+	for _, r := range runs {
+		rh := parseHistory(r.out)
+		if m.config == nil {
+			m.config = rh.config
+		}
+		for _, line := range rh.results {
+			if line, ok := addVersion(line, r.ver); ok {
+				m.results = append(m.results, line)
+			}
+		}
+	}
+	return m
+}
+
+// addVersion adds the /ver= key to the name of a govalues benchmark.
+// It returns false if the line does not belong to a govalues benchmark.
+func addVersion(line, ver string) (string, bool) {
+	pos := strings.Index(line, modKey)
+	if pos < 0 {
+		return "", false
+	}
+	pos += len(modKey)
+	if pos < len(line) && line[pos] != '/' && line[pos] != '-' && line[pos] != ' ' && line[pos] != '\t' {
+		return "", false
+	}
+	return line[:pos] + verKey + ver + line[pos:], true
+}
+
+// version returns the value of the /ver= key of a benchmark line.
+func version(line string) string {
+	name, _, _ := strings.Cut(line, "\t")
+	name = strings.TrimSpace(name)
+	_, ver, ok := strings.Cut(name, verKey)
+	if !ok {
+		return ""
+	}
+	if pos := strings.IndexByte(ver, '/'); pos >= 0 {
+		ver = ver[:pos]
+	}
+	// GOMAXPROCS suffix
+	if pos := strings.LastIndexByte(ver, '-'); pos >= 0 && isDigits(ver[pos+1:]) {
+		ver = ver[:pos]
+	}
+	return ver
+}
+
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i := range len(s) {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+func (h history) bytes() []byte {
+	var buf bytes.Buffer
+	for _, line := range h.config {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	for _, line := range h.results {
+		buf.WriteString(line)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,164 @@
+package main
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAddVersion(t *testing.T) {
+	tests := []struct {
+		line   string
+		want   string
+		wantOk bool
+	}{
+		{
+			"BenchmarkDecimal_Add/5+6/mod=govalues         \t46922866\t        26.29 ns/op",
+			"BenchmarkDecimal_Add/5+6/mod=govalues/ver=v0.1.35         \t46922866\t        26.29 ns/op",
+			true,
+		},
+		{
+			"BenchmarkDecimal_Add/5+6/mod=govalues-8\t46922866\t26.29 ns/op",
+			"BenchmarkDecimal_Add/5+6/mod=govalues/ver=v0.1.35-8\t46922866\t26.29 ns/op",
+			true,
+		},
+		{
+			"BenchmarkDecimal_Telco/mod=govalues/procs=4\t100\t2629 ns/op",
+			"BenchmarkDecimal_Telco/mod=govalues/ver=v0.1.35/procs=4\t100\t2629 ns/op",
+			true,
+		},
+		{
+			"BenchmarkDecimal_Add/5+6/mod=cockroachdb\t12924774\t102.7 ns/op",
+			"",
+			false,
+		},
+		{
+			"BenchmarkDecimal_Add/5+6/mod=govaluesx\t12924774\t102.7 ns/op",
+			"",
+			false,
+		},
+	}
+	for _, tt := range tests {
+		got, ok := addVersion(tt.line, "v0.1.35")
+		if got != tt.want || ok != tt.wantOk {
+			t.Errorf("addVersion(%q) = %q, %v, want %q, %v", tt.line, got, ok, tt.want, tt.wantOk)
+		}
+	}
+}
+
+func TestVersion(t *testing.T) {
+	tests := []struct {
+		line string
+		want string
+	}{
+		{"BenchmarkDecimal_Add/5+6/mod=govalues/ver=v0.1.35\t1\t26.29 ns/op", "v0.1.35"},
+		{"BenchmarkDecimal_Add/5+6/mod=govalues/ver=v0.1.35-8\t1\t26.29 ns/op", "v0.1.35"},
+		{"BenchmarkDecimal_Telco/mod=govalues/ver=v0.1.35/procs=4\t1\t26.29 ns/op", "v0.1.35"},
+		{"BenchmarkDecimal_Add/5+6/mod=govalues\t1\t26.29 ns/op", ""},
+	}
+	for _, tt := range tests {
+		got := version(tt.line)
+		if got != tt.want {
+			t.Errorf("version(%q) = %q, want %q", tt.line, got, tt.want)
+		}
+	}
+}
+
+func TestMerge(t *testing.T) {
+	old := parseHistory([]byte(`goos: linux
+goarch: amd64
+BenchmarkParse/1/mod=govalues/ver=v0.1.34	10	1 ns/op
+BenchmarkParse/1/mod=govalues/ver=v0.1.35	10	2 ns/op
+`))
+	runs := []run{
+		{
+			ver: "v0.1.35",
+			out: []byte(`goos: linux
+goarch: amd64
+pkg: github.com/govalues/decimal-tests/bench
+BenchmarkParse/1/mod=govalues	10	3 ns/op
+BenchmarkParse/1/mod=shopspring	10	4 ns/op
+--- FAIL: BenchmarkDecimal_Telco: open expon180.1e6b: no such file or directory
+PASS
+ok  	github.com/govalues/decimal-tests/bench	4.091s
+`),
+		},
+	}
+	got := merge(old, runs)
+	wantConfig := []string{
+		"goos: linux",
+		"goarch: amd64",
+	}
+	if !slices.Equal(got.config, wantConfig) {
+		t.Errorf("merge().config = %q, want %q", got.config, wantConfig)
+	}
+	wantResults := []string{
+		"BenchmarkParse/1/mod=govalues/ver=v0.1.34\t10\t1 ns/op",
+		"BenchmarkParse/1/mod=govalues/ver=v0.1.35\t10\t3 ns/op",
+	}
+	if !slices.Equal(got.results, wantResults) {
+		t.Errorf("merge().results = %q, want %q", got.results, wantResults)
+	}
+}
+
+func TestGovaluesPatterns(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"Decimal_Telco/mod=govalues", true},
+		{"Decimal_Telco/mod=shopspring", false},
+		{"Decimal_Add/size=small/mod=govalues", true},
+		{"Decimal_Add/size=small/mod=cockroachdb", false},
+		{"Decimal_Add/5+6/mod=int64", false},
+		{"Decimal_AddParallel/size=money/mod=govalues/procs=2", true},
+		{"Decimal_AddParallel/size=money/mod=shopspring/procs=2", false},
+		{"Workload/OrderBook/mod=govalues", true},
+		{"Workload/OrderBook/mod=float64", false},
+	}
+	patterns := govaluesPatterns(".")
+	for _, tt := range tests {
+		// A benchmark is reported by a pattern if it has at least as many
+		// elements as the pattern and every element of the pattern matches.
+		elems := strings.Split(tt.name, "/")
+		got := 0
+		for _, pattern := range patterns {
+			parts := strings.Split(pattern, "/")
+			if len(elems) < len(parts) {
+				continue
+			}
+			ok := true
+			for i, part := range parts {
+				ok = ok && regexp.MustCompile(part).MatchString(elems[i])
+			}
+			if ok {
+				got++
+			}
+		}
+		want := 0
+		if tt.want {
+			want = 1
+		}
+		if got != want {
+			t.Errorf("govaluesPatterns(%q) select %q %v times, want %v", ".", tt.name, got, want)
+		}
+	}
+}
+
+func TestParseHistory(t *testing.T) {
+	h := parseHistory([]byte(`goos: linux
+goarch: amd64
+BenchmarkParse/1/mod=govalues	10	1 ns/op
+goos: linux
+goarch: amd64
+BenchmarkDecimal_Add/5+6/mod=govalues	10	2 ns/op
+`))
+	wantConfig := []string{"goos: linux", "goarch: amd64"}
+	if !slices.Equal(h.config, wantConfig) {
+		t.Errorf("parseHistory().config = %q, want %q", h.config, wantConfig)
+	}
+	if len(h.results) != 2 {
+		t.Errorf("parseHistory().results = %q, want 2 lines", h.results)
+	}
+}
@@ -0,0 +1,576 @@
+package decimal_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	gv "github.com/govalues/decimal"
+	gvnext "github.com/govalues/decimal-next"
+	"github.com/govalues/decimal-tests/internal/corpus"
+)
+
+// change describes a reviewed difference between the pinned and the next version.
+// A nil match accepts all differences of the operation.
+type change struct {
+	op     string
+	reason string
+	match  func(input string, got, was outcome) bool
+}
+
+// changes lists the differences that are intended fixes.
+// Every difference that is not listed here is reported as a regression.
+// The list must be reviewed each time the next version is bumped.
+var changes = []change{
+	{
+		op:     "Exp",
+		reason: "v0.1.36 wraps overflow errors with the argument",
+		match: func(_ string, got, was outcome) bool {
+			return was.err != "" && strings.HasPrefix(got.err, "computing exp(") && strings.HasSuffix(got.err, ": "+was.err)
+		},
+	},
+}
+
+// outcome is the observable result of an operation.
+type outcome struct {
+	value string
+	scale int
+	err   string
+}
+
+func (o outcome) String() string {
+	if o.err != "" {
+		return fmt.Sprintf("error %q", o.err)
+	}
+	return fmt.Sprintf("%v (scale=%v)", o.value, o.scale)
+}
+
+// decimal is a set of methods shared by all compared versions.
+type decimal[T any] interface {
+	fmt.Stringer
+	fmt.Formatter
+	Scale() int
+	Prec() int
+	MinScale() int
+	Sign() int
+	IsZero() bool
+	IsNeg() bool
+	IsPos() bool
+	IsInt() bool
+	IsOne() bool
+	WithinOne() bool
+	Coef() uint64
+	ULP() T
+	Neg() T
+	Abs() T
+	CopySign(T) T
+	Inv() (T, error)
+	Round(int) T
+	Trunc(int) T
+	Ceil(int) T
+	Floor(int) T
+	Pad(int) T
+	Rescale(int) T
+	Trim(int) T
+	Quantize(T) T
+	Int64(int) (int64, int64, bool)
+	Float64() (float64, bool)
+	Add(T) (T, error)
+	AddExact(T, int) (T, error)
+	Sub(T) (T, error)
+	SubExact(T, int) (T, error)
+	SubAbs(T) (T, error)
+	Mul(T) (T, error)
+	MulExact(T, int) (T, error)
+	Quo(T) (T, error)
+	QuoExact(T, int) (T, error)
+	QuoRem(T) (T, T, error)
+	AddMul(T, T) (T, error)
+	AddMulExact(T, T, int) (T, error)
+	SubMul(T, T) (T, error)
+	SubMulExact(T, T, int) (T, error)
+	AddQuo(T, T) (T, error)
+	AddQuoExact(T, T, int) (T, error)
+	SubQuo(T, T) (T, error)
+	SubQuoExact(T, T, int) (T, error)
+	Pow(T) (T, error)
+	PowInt(int) (T, error)
+	Sqrt() (T, error)
+	Exp() (T, error)
+	Log() (T, error)
+	Log2() (T, error)
+	Log10() (T, error)
+	Cmp(T) int
+	CmpAbs(T) int
+	CmpTotal(T) int
+	Equal(T) bool
+	Less(T) bool
+	SameScale(T) bool
+	Max(T) T
+	Min(T) T
+	Clamp(T, T) (T, error)
+	MarshalText() ([]byte, error)
+	MarshalBinary() ([]byte, error)
+	MarshalJSON() ([]byte, error)
+	MarshalBSONValue() (byte, []byte, error)
+	Value() (driver.Value, error)
+}
+
+// pointer is a set of methods of decimal pointers shared by all compared versions.
+type pointer[T any] interface {
+	*T
+	sql.Scanner
+	UnmarshalText([]byte) error
+	UnmarshalJSON([]byte) error
+	UnmarshalBinary([]byte) error
+	UnmarshalBSONValue(byte, []byte) error
+}
+
+// nullDecimal is a set of methods of null decimal pointers shared by all compared versions.
+type nullDecimal interface {
+	driver.Valuer
+	sql.Scanner
+	json.Marshaler
+	json.Unmarshaler
+	MarshalBSONValue() (byte, []byte, error)
+	UnmarshalBSONValue(byte, []byte) error
+}
+
+// library is a set of functions shared by all compared versions.
+type library[T decimal[T]] struct {
+	new            func(int64, int) (T, error)
+	newFromInt64   func(int64, int64, int) (T, error)
+	newFromFloat64 func(float64) (T, error)
+	parse          func(string) (T, error)
+	parseExact     func(string, int) (T, error)
+	sum            func(...T) (T, error)
+	prod           func(...T) (T, error)
+	mean           func(...T) (T, error)
+	newNull        func(T, bool) nullDecimal
+}
+
+var (
+	libGV = library[gv.Decimal]{
+		new:            gv.New,
+		newFromInt64:   gv.NewFromInt64,
+		newFromFloat64: gv.NewFromFloat64,
+		parse:          gv.Parse,
+		parseExact:     gv.ParseExact,
+		sum:            gv.Sum,
+		prod:           gv.Prod,
+		mean:           gv.Mean,
+		newNull: func(d gv.Decimal, valid bool) nullDecimal {
+			return &gv.NullDecimal{Decimal: d, Valid: valid}
+		},
+	}
+	libNext = library[gvnext.Decimal]{
+		new:            gvnext.New,
+		newFromInt64:   gvnext.NewFromInt64,
+		newFromFloat64: gvnext.NewFromFloat64,
+		parse:          gvnext.Parse,
+		parseExact:     gvnext.ParseExact,
+		sum:            gvnext.Sum,
+		prod:           gvnext.Prod,
+		mean:           gvnext.Mean,
+		newNull: func(d gvnext.Decimal, valid bool) nullDecimal {
+			return &gvnext.NullDecimal{Decimal: d, Valid: valid}
+		},
+	}
+)
+
+type operation struct {
+	name string
+	fn   func() outcome
+}
+
+func decimalOutcome[T decimal[T]](d T, err error) outcome {
+	if err != nil {
+		return outcome{err: err.Error()}
+	}
+	return outcome{value: d.String(), scale: d.Scale()}
+}
+
+func bytesOutcome(b []byte, err error) outcome {
+	if err != nil {
+		return outcome{err: err.Error()}
+	}
+	return outcome{value: string(b)}
+}
+
+func valueOutcome(v ...any) outcome {
+	return outcome{value: fmt.Sprint(v...)}
+}
+
+func bsonOutcome(typ byte, data []byte, err error) outcome {
+	if err != nil {
+		return outcome{err: err.Error()}
+	}
+	return valueOutcome(fmt.Sprintf("%x %x", typ, data))
+}
+
+func driverOutcome(v driver.Value, err error) outcome {
+	if err != nil {
+		return outcome{err: err.Error()}
+	}
+	return valueOutcome(fmt.Sprintf("%T %v", v, v))
+}
+
+// unmarshal returns the decimal decoded by fn.
+func unmarshal[T decimal[T], P pointer[T]](fn func(P) error) outcome {
+	var d T
+	return decimalOutcome(d, fn(&d))
+}
+
+// nullOutcome returns the decimal and validity of a null decimal.
+func nullOutcome(n nullDecimal, err error) outcome {
+	if err != nil {
+		return outcome{err: err.Error()}
+	}
+	return valueOutcome(fmt.Sprintf("%v", n))
+}
+
+// unmarshalNull returns the null decimal decoded by fn.
+func unmarshalNull[T decimal[T]](lib library[T], fn func(nullDecimal) error) outcome {
+	var zero T
+	n := lib.newNull(zero, false)
+	return nullOutcome(n, fn(n))
+}
+
+// nullBSON is the BSON type of null values.
+const nullBSON = 0x0A
+
+func unaryOps[T decimal[T], P pointer[T]](lib library[T], d T) []operation {
+	text, _ := d.MarshalText()
+	bin, _ := d.MarshalBinary()
+	js, _ := d.MarshalJSON()
+	typ, data, _ := d.MarshalBSONValue()
+	whole, _, _ := d.Int64(0)
+	f, _ := d.Float64()
+	return []operation{
+		{"String", func() outcome { return valueOutcome(d.String()) }},
+		{"Format", func() outcome { return valueOutcome(fmt.Sprintf("%v|%.2f|%+020.5f|%q|%k|%.1k", d, d, d, d, d, d)) }},
+		{"Prec", func() outcome { return valueOutcome(d.Prec()) }},
+		{"MinScale", func() outcome { return valueOutcome(d.MinScale()) }},
+		{"Sign", func() outcome { return valueOutcome(d.Sign()) }},
+		{"IsZero", func() outcome { return valueOutcome(d.IsZero()) }},
+		{"IsNeg", func() outcome { return valueOutcome(d.IsNeg()) }},
+		{"IsPos", func() outcome { return valueOutcome(d.IsPos()) }},
+		{"IsInt", func() outcome { return valueOutcome(d.IsInt()) }},
+		{"IsOne", func() outcome { return valueOutcome(d.IsOne()) }},
+		{"WithinOne", func() outcome { return valueOutcome(d.WithinOne()) }},
+		{"Coef", func() outcome { return valueOutcome(d.Coef()) }},
+		{"ULP", func() outcome { return decimalOutcome(d.ULP(), nil) }},
+		{"Neg", func() outcome { return decimalOutcome(d.Neg(), nil) }},
+		{"Abs", func() outcome { return decimalOutcome(d.Abs(), nil) }},
+		{"Inv", func() outcome { return decimalOutcome(d.Inv()) }},
+		{"Sqrt", func() outcome { return decimalOutcome(d.Sqrt()) }},
+		{"Exp", func() outcome { return decimalOutcome(d.Exp()) }},
+		{"Log", func() outcome { return decimalOutcome(d.Log()) }},
+		{"Log2", func() outcome { return decimalOutcome(d.Log2()) }},
+		{"Log10", func() outcome { return decimalOutcome(d.Log10()) }},
+		{"Float64", func() outcome {
+			f, ok := d.Float64()
+			return valueOutcome(strconv.FormatFloat(f, 'g', -1, 64), " ", ok)
+		}},
+		{"NewFromFloat64", func() outcome {
+			f, _ := d.Float64()
+			return decimalOutcome(lib.newFromFloat64(f))
+		}},
+		{"Parse", func() outcome { return decimalOutcome(lib.parse(d.String())) }},
+		{"MarshalText", func() outcome { return bytesOutcome(d.MarshalText()) }},
+		{"MarshalBinary", func() outcome { return bytesOutcome(d.MarshalBinary()) }},
+		{"MarshalJSON", func() outcome { return bytesOutcome(d.MarshalJSON()) }},
+		{"MarshalBSONValue", func() outcome { return bsonOutcome(d.MarshalBSONValue()) }},
+		{"UnmarshalText", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalText(text) }) }},
+		{"UnmarshalBinary", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalBinary(bin) }) }},
+		{"UnmarshalJSON", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalJSON(js) }) }},
+		{"UnmarshalBSONValue", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalBSONValue(typ, data) }) }},
+		{"Value", func() outcome { return driverOutcome(d.Value()) }},
+		{"Scan", func() outcome { return unmarshal(func(p P) error { return p.Scan(d.String()) }) }},
+		{"ScanBytes", func() outcome { return unmarshal(func(p P) error { return p.Scan(text) }) }},
+		{"ScanInt64", func() outcome { return unmarshal(func(p P) error { return p.Scan(whole) }) }},
+		{"ScanFloat64", func() outcome { return unmarshal(func(p P) error { return p.Scan(f) }) }},
+		{"ScanNil", func() outcome { return unmarshal(func(p P) error { return p.Scan(nil) }) }},
+		{"NullDecimal.Value", func() outcome { return driverOutcome(lib.newNull(d, true).Value()) }},
+		{"NullDecimal.ValueNull", func() outcome { return driverOutcome(lib.newNull(d, false).Value()) }},
+		{"NullDecimal.MarshalJSON", func() outcome { return bytesOutcome(lib.newNull(d, true).MarshalJSON()) }},
+		{"NullDecimal.MarshalJSONNull", func() outcome { return bytesOutcome(lib.newNull(d, false).MarshalJSON()) }},
+		{"NullDecimal.MarshalBSONValue", func() outcome { return bsonOutcome(lib.newNull(d, true).MarshalBSONValue()) }},
+		{"NullDecimal.MarshalBSONValueNull", func() outcome { return bsonOutcome(lib.newNull(d, false).MarshalBSONValue()) }},
+		{"NullDecimal.Scan", func() outcome { return unmarshalNull(lib, func(n nullDecimal) error { return n.Scan(d.String()) }) }},
+		{"NullDecimal.ScanNil", func() outcome { return unmarshalNull(lib, func(n nullDecimal) error { return n.Scan(nil) }) }},
+		{"NullDecimal.UnmarshalJSON", func() outcome { return unmarshalNull(lib, func(n nullDecimal) error { return n.UnmarshalJSON(js) }) }},
+		{"NullDecimal.UnmarshalJSONNull", func() outcome {
+			return unmarshalNull(lib, func(n nullDecimal) error { return n.UnmarshalJSON([]byte("null")) })
+		}},
+		{"NullDecimal.UnmarshalBSONValue", func() outcome {
+			return unmarshalNull(lib, func(n nullDecimal) error { return n.UnmarshalBSONValue(typ, data) })
+		}},
+		{"NullDecimal.UnmarshalBSONValueNull", func() outcome {
+			return unmarshalNull(lib, func(n nullDecimal) error { return n.UnmarshalBSONValue(nullBSON, nil) })
+		}},
+	}
+}
+
+func scaleOps[T decimal[T]](lib library[T], d T, n int) []operation {
+	return []operation{
+		{"Round", func() outcome { return decimalOutcome(d.Round(n), nil) }},
+		{"Trunc", func() outcome { return decimalOutcome(d.Trunc(n), nil) }},
+		{"Ceil", func() outcome { return decimalOutcome(d.Ceil(n), nil) }},
+		{"Floor", func() outcome { return decimalOutcome(d.Floor(n), nil) }},
+		{"Pad", func() outcome { return decimalOutcome(d.Pad(n), nil) }},
+		{"Rescale", func() outcome { return decimalOutcome(d.Rescale(n), nil) }},
+		{"Trim", func() outcome { return decimalOutcome(d.Trim(n), nil) }},
+		{"PowInt", func() outcome { return decimalOutcome(d.PowInt(n)) }},
+		{"ParseExact", func() outcome { return decimalOutcome(lib.parseExact(d.String(), n)) }},
+		{"Int64", func() outcome {
+			whole, frac, ok := d.Int64(n)
+			return valueOutcome(whole, " ", frac, " ", ok)
+		}},
+		{"NewFromInt64", func() outcome {
+			whole, frac, _ := d.Int64(n)
+			return decimalOutcome(lib.newFromInt64(whole, frac, n))
+		}},
+	}
+}
+
+func binaryOps[T decimal[T]](d, e T) []operation {
+	return []operation{
+		{"Add", func() outcome { return decimalOutcome(d.Add(e)) }},
+		{"AddExact", func() outcome { return decimalOutcome(d.AddExact(e, e.Scale())) }},
+		{"Sub", func() outcome { return decimalOutcome(d.Sub(e)) }},
+		{"SubExact", func() outcome { return decimalOutcome(d.SubExact(e, e.Scale())) }},
+		{"SubAbs", func() outcome { return decimalOutcome(d.SubAbs(e)) }},
+		{"Mul", func() outcome { return decimalOutcome(d.Mul(e)) }},
+		{"MulExact", func() outcome { return decimalOutcome(d.MulExact(e, e.Scale())) }},
+		{"Quo", func() outcome { return decimalOutcome(d.Quo(e)) }},
+		{"QuoExact", func() outcome { return decimalOutcome(d.QuoExact(e, e.Scale())) }},
+		{"QuoRem", func() outcome {
+			q, r, err := d.QuoRem(e)
+			if err != nil {
+				return outcome{err: err.Error()}
+			}
+			return outcome{value: q.String() + " " + r.String(), scale: q.Scale()}
+		}},
+		{"Pow", func() outcome { return decimalOutcome(d.Pow(e)) }},
+		{"Cmp", func() outcome { return valueOutcome(d.Cmp(e)) }},
+		{"CmpAbs", func() outcome { return valueOutcome(d.CmpAbs(e)) }},
+		{"CmpTotal", func() outcome { return valueOutcome(d.CmpTotal(e)) }},
+		{"Equal", func() outcome { return valueOutcome(d.Equal(e)) }},
+		{"Less", func() outcome { return valueOutcome(d.Less(e)) }},
+		{"SameScale", func() outcome { return valueOutcome(d.SameScale(e)) }},
+		{"Max", func() outcome { return decimalOutcome(d.Max(e), nil) }},
+		{"Min", func() outcome { return decimalOutcome(d.Min(e), nil) }},
+		{"CopySign", func() outcome { return decimalOutcome(d.CopySign(e), nil) }},
+		{"Quantize", func() outcome { return decimalOutcome(d.Quantize(e), nil) }},
+	}
+}
+
+func ternaryOps[T decimal[T]](lib library[T], d, e, f T) []operation {
+	return []operation{
+		{"AddMul", func() outcome { return decimalOutcome(d.AddMul(e, f)) }},
+		{"AddMulExact", func() outcome { return decimalOutcome(d.AddMulExact(e, f, f.Scale())) }},
+		{"SubMul", func() outcome { return decimalOutcome(d.SubMul(e, f)) }},
+		{"SubMulExact", func() outcome { return decimalOutcome(d.SubMulExact(e, f, f.Scale())) }},
+		{"AddQuo", func() outcome { return decimalOutcome(d.AddQuo(e, f)) }},
+		{"AddQuoExact", func() outcome { return decimalOutcome(d.AddQuoExact(e, f, f.Scale())) }},
+		{"SubQuo", func() outcome { return decimalOutcome(d.SubQuo(e, f)) }},
+		{"SubQuoExact", func() outcome { return decimalOutcome(d.SubQuoExact(e, f, f.Scale())) }},
+		{"Clamp", func() outcome { return decimalOutcome(d.Clamp(e, f)) }},
+		{"Sum", func() outcome { return decimalOutcome(lib.sum(d, e, f)) }},
+		{"Prod", func() outcome { return decimalOutcome(lib.prod(d, e, f)) }},
+		{"Mean", func() outcome { return decimalOutcome(lib.mean(d, e, f)) }},
+	}
+}
+
+// parseOps decodes arbitrary text and bytes.
+func parseOps[T decimal[T], P pointer[T]](lib library[T], s string) []operation {
+	b := []byte(s)
+	return []operation{
+		{"Parse", func() outcome { return decimalOutcome(lib.parse(s)) }},
+		{"ParseExact", func() outcome { return decimalOutcome(lib.parseExact(s, 2)) }},
+		{"UnmarshalText", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalText(b) }) }},
+		{"UnmarshalBinary", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalBinary(b) }) }},
+		{"UnmarshalJSON", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalJSON(b) }) }},
+		{"UnmarshalBSONValueString", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalBSONValue(0x02, b) }) }},
+		{"UnmarshalBSONValueDecimal128", func() outcome { return unmarshal(func(p P) error { return p.UnmarshalBSONValue(0x13, b) }) }},
+		{"Scan", func() outcome { return unmarshal(func(p P) error { return p.Scan(s) }) }},
+		{"ScanBytes", func() outcome { return unmarshal(func(p P) error { return p.Scan(b) }) }},
+		{"NullDecimal.Scan", func() outcome { return unmarshalNull(lib, func(n nullDecimal) error { return n.Scan(s) }) }},
+		{"NullDecimal.UnmarshalJSON", func() outcome { return unmarshalNull(lib, func(n nullDecimal) error { return n.UnmarshalJSON(b) }) }},
+	}
+}
+
+// compare runs the same operations in both versions and reports every difference.
+func compare(t *testing.T, input string, got, want []operation) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("operation lists differ in length: %v != %v", len(got), len(want))
+	}
+	for i := range got {
+		if got[i].name != want[i].name {
+			t.Fatalf("operation lists differ at %v: %v != %v", i, got[i].name, want[i].name)
+		}
+		gotO, wantO := got[i].fn(), want[i].fn()
+		if gotO == wantO {
+			continue
+		}
+		name := got[i].name
+		if reason, ok := lookupChange(name, input, gotO, wantO); ok {
+			t.Logf("fix: %v(%v) = %v, was %v: %v", name, input, gotO, wantO, reason)
+			continue
+		}
+		t.Errorf("regression: %v(%v) = %v, was %v", name, input, gotO, wantO)
+	}
+}
+
+func lookupChange(op, input string, got, was outcome) (string, bool) {
+	for _, c := range changes {
+		if c.op == op && (c.match == nil || c.match(input, got, was)) {
+			return c.reason, true
+		}
+	}
+	return "", false
+}
+
+// construct returns decimals from both versions if both versions agree on the result.
+// It reports a regression otherwise.
+func construct(t *testing.T, coef int64, scale int) (gv.Decimal, gvnext.Decimal, bool) {
+	t.Helper()
+	d, err := libGV.new(coef, scale)
+	dNext, errNext := libNext.new(coef, scale)
+	input := fmt.Sprintf("%v, %v", coef, scale)
+	compare(t, input,
+		[]operation{{"New", func() outcome { return decimalOutcome(dNext, errNext) }}},
+		[]operation{{"New", func() outcome { return decimalOutcome(d, err) }}},
+	)
+	return d, dNext, err == nil && errNext == nil
+}
+
+func FuzzDecimal_Unary(f *testing.F) {
+	for _, d := range corpus.Decimals {
+		f.Add(d.Coef, d.Scale)
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int) {
+		d, dNext, ok := construct(t, dcoef, dscale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		input := fmt.Sprintf("%v, %v", dcoef, dscale)
+		compare(t, input, unaryOps(libNext, dNext), unaryOps(libGV, d))
+	})
+}
+
+func FuzzDecimal_Scale(f *testing.F) {
+	for _, d := range corpus.Decimals {
+		for n := -2; n <= 21; n++ {
+			f.Add(d.Coef, d.Scale, n)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, n int) {
+		d, dNext, ok := construct(t, dcoef, dscale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		input := fmt.Sprintf("%v, %v, %v", dcoef, dscale, n)
+		compare(t, input, scaleOps(libNext, dNext, n), scaleOps(libGV, d, n))
+	})
+}
+
+func FuzzDecimal_Binary(f *testing.F) {
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			f.Add(d.Coef, d.Scale, e.Coef, e.Scale)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, ecoef int64, escale int) {
+		d, dNext, ok := construct(t, dcoef, dscale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		e, eNext, ok := construct(t, ecoef, escale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		input := fmt.Sprintf("%v, %v, %v, %v", dcoef, dscale, ecoef, escale)
+		compare(t, input, binaryOps(dNext, eNext), binaryOps(d, e))
+	})
+}
+
+func FuzzDecimal_Ternary(f *testing.F) {
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, g := range corpus.Decimals {
+				f.Add(d.Coef, d.Scale, e.Coef, e.Scale, g.Coef, g.Scale)
+			}
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) {
+		d, dNext, ok := construct(t, dcoef, dscale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		e, eNext, ok := construct(t, ecoef, escale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		g, gNext, ok := construct(t, fcoef, fscale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		input := fmt.Sprintf("%v, %v, %v, %v, %v, %v", dcoef, dscale, ecoef, escale, fcoef, fscale)
+		compare(t, input, ternaryOps(libNext, dNext, eNext, gNext), ternaryOps(libGV, d, e, g))
+	})
+}
+
+func FuzzParse(f *testing.F) {
+	for _, d := range corpus.Decimals {
+		f.Add(gv.MustNew(d.Coef, d.Scale).String())
+	}
+	f.Add("")
+	f.Add("-")
+	f.Add(".5")
+	f.Add("1e-19")
+	f.Add("1E+18")
+	f.Add("0.00000000000000000005")
+	f.Add("99999999999999999999")
+	f.Add("+1.2.3")
+
+	f.Fuzz(func(t *testing.T, s string) {
+		compare(t, strconv.Quote(s), parseOps(libNext, s), parseOps(libGV, s))
+	})
+}
+
+func FuzzNewFromFloat64(f *testing.F) {
+	for _, d := range corpus.Decimals {
+		v, _ := gv.MustNew(d.Coef, d.Scale).Float64()
+		f.Add(v)
+	}
+	f.Add(math.SmallestNonzeroFloat64)
+	f.Add(math.MaxFloat64)
+	f.Add(math.Inf(1))
+	f.Add(math.NaN())
+
+	f.Fuzz(func(t *testing.T, v float64) {
+		compare(t, strconv.FormatFloat(v, 'g', -1, 64),
+			[]operation{{"NewFromFloat64", func() outcome { return decimalOutcome(libNext.newFromFloat64(v)) }}},
+			[]operation{{"NewFromFloat64", func() outcome { return decimalOutcome(libGV.newFromFloat64(v)) }}},
+		)
+	})
+}
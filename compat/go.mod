@@ -0,0 +1,16 @@
+module github.com/govalues/decimal-tests/compat
+
+go 1.23
+
+require (
+	github.com/govalues/decimal v0.1.35
+	github.com/govalues/decimal-next v0.0.0
+	github.com/govalues/decimal-tests v0.0.0
+)
+
+// The pinned version must match the one in the root go.mod.
+// The next version is the release candidate for an upgrade.
+replace github.com/govalues/decimal-next => github.com/govalues/decimal v0.1.36
+
+// The seed corpus is shared with the fuzz tests of the root module.
+replace github.com/govalues/decimal-tests => ../
@@ -0,0 +1,293 @@
+// Package convert converts decimals between [govalues/decimal],
+// [shopspring/decimal], [cockroachdb/apd], and [big.Rat].
+//
+// Conversions to govalues are exact if the value fits 19 digits,
+// with at most 19 digits after the decimal point.
+// Otherwise, the digits after the decimal point are rounded half to even
+// and the rounded value is returned with [ErrInexact],
+// much like [strconv.ParseFloat] returns a value with [strconv.ErrRange].
+// If the integer part does not fit 19 digits, the conversion fails
+// with [ErrOverflow].
+// The scale of the source is kept, so that 1.50 is converted to 1.50;
+// rationals have no scale and are converted with the smallest scale
+// that represents them.
+//
+// Conversions from govalues are always exact.
+// Between shopspring and apd, only special values of apd cannot be converted.
+//
+// [govalues/decimal]: https://github.com/govalues/decimal
+// [shopspring/decimal]: https://github.com/shopspring/decimal
+// [cockroachdb/apd]: https://github.com/cockroachdb/apd
+package convert
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	ss "github.com/shopspring/decimal"
+)
+
+var (
+	// ErrInexact is returned with a rounded value if the source has more
+	// digits than govalues can represent.
+	ErrInexact = errors.New("inexact conversion")
+	// ErrOverflow is returned if the integer part of the source has more
+	// than 19 digits.
+	ErrOverflow = errors.New("conversion overflow")
+	// ErrNotFinite is returned if the source is an infinity or a NaN.
+	ErrNotFinite = errors.New("not a finite number")
+)
+
+// pow10Table holds powers of ten up to the 40 digits that a 19-digit
+// coefficient is multiplied or divided by.
+var pow10Table = func() [41]*big.Int {
+	var t [41]*big.Int
+	t[0] = big.NewInt(1)
+	for i := 1; i < len(t); i++ {
+		t[i] = new(big.Int).Mul(t[i-1], big.NewInt(10))
+	}
+	return t
+}()
+
+// pow10 returns 10^n, which must not be modified.
+func pow10(n int) *big.Int {
+	if n < len(pow10Table) {
+		return pow10Table[n]
+	}
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// maxCoef is 10^19, the smallest coefficient that does not fit govalues.
+var maxCoef = pow10(gv.MaxPrec)
+
+// numDigits returns the number of decimal digits of x, which must be positive.
+func numDigits(x *big.Int) int {
+	// The estimate is exact or one too large.
+	n := int(float64(x.BitLen())*math.Log10(2)) + 1
+	if x.CmpAbs(pow10(n-1)) < 0 {
+		n--
+	}
+	return n
+}
+
+// newGV returns the decimal with the coefficient and scale,
+// which must fit govalues.
+func newGV(neg bool, coef uint64, scale int) (gv.Decimal, error) {
+	if coef <= math.MaxInt64 {
+		c := int64(coef)
+		if neg {
+			c = -c
+		}
+		return gv.New(c, scale)
+	}
+	// The coefficient has 19 digits
+	s := strconv.FormatUint(coef, 10)
+	if scale > 0 {
+		s = "0" + s[:len(s)-scale] + "." + s[len(s)-scale:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return gv.Parse(s)
+}
+
+// roundQuo returns x / y rounded half to even, and reports whether
+// the quotient is exact. The operands must be non-negative.
+func roundQuo(x, y *big.Int) (*big.Int, bool) {
+	q, r := new(big.Int).QuoRem(x, y, new(big.Int))
+	if r.Sign() == 0 {
+		return q, true
+	}
+	switch r.Lsh(r, 1).Cmp(y) {
+	case 1:
+		q.Add(q, big.NewInt(1))
+	case 0:
+		if q.Bit(0) == 1 {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return q, false
+}
+
+// fromBig returns the decimal coef * 10^exp, where coef is non-negative.
+// The coefficient is rounded at most once.
+func fromBig(neg bool, coef *big.Int, exp int) (gv.Decimal, error) {
+	if coef.Sign() == 0 {
+		return newGV(false, 0, -max(min(exp, 0), -gv.MaxScale))
+	}
+	n := numDigits(coef)
+	if n+exp > gv.MaxPrec {
+		return gv.Decimal{}, ErrOverflow
+	}
+	if exp > 0 {
+		coef = new(big.Int).Mul(coef, pow10(exp))
+		n, exp = n+exp, 0
+	}
+	if exp < -gv.MaxScale-n {
+		// The value is below half of the smallest step, and the power
+		// of ten that it would be divided by is not built.
+		d, err := newGV(false, 0, gv.MaxScale)
+		if err != nil {
+			return gv.Decimal{}, err
+		}
+		return d, ErrInexact
+	}
+	scale := -exp
+	exact := true
+	if drop := max(scale-gv.MaxScale, n-gv.MaxPrec, 0); drop > 0 {
+		coef, exact = roundQuo(coef, pow10(drop))
+		scale -= drop
+		if coef.Cmp(maxCoef) == 0 {
+			// Rounding carried into the 20th digit
+			if scale == 0 {
+				return gv.Decimal{}, ErrOverflow
+			}
+			coef.Quo(coef, big.NewInt(10))
+			scale--
+		}
+	}
+	d, err := newGV(neg && coef.Sign() != 0, coef.Uint64(), scale)
+	if err != nil {
+		return gv.Decimal{}, err
+	}
+	if !exact {
+		return d, ErrInexact
+	}
+	return d, nil
+}
+
+// coefBig returns the coefficient of d as a big integer.
+func coefBig(d gv.Decimal) *big.Int {
+	c := new(big.Int).SetUint64(d.Coef())
+	if d.IsNeg() {
+		c.Neg(c)
+	}
+	return c
+}
+
+// GVToSS converts a govalues decimal to a shopspring decimal.
+// The conversion is exact.
+func GVToSS(d gv.Decimal) ss.Decimal {
+	exp := int32(-d.Scale())
+	if c := d.Coef(); c <= math.MaxInt64 {
+		if d.IsNeg() {
+			return ss.New(-int64(c), exp)
+		}
+		return ss.New(int64(c), exp)
+	}
+	return ss.NewFromBigInt(coefBig(d), exp)
+}
+
+// SSToGV converts a shopspring decimal to a govalues decimal.
+// See the package documentation for rounding and errors.
+func SSToGV(d ss.Decimal) (gv.Decimal, error) {
+	exp := int(d.Exponent())
+	if exp <= 0 && exp >= -gv.MaxScale && d.NumDigits() < gv.MaxPrec {
+		// Fast path for coefficients that fit int64
+		return gv.New(d.CoefficientInt64(), -exp)
+	}
+	c := d.Coefficient()
+	neg := c.Sign() < 0
+	return fromBig(neg, c.Abs(c), exp)
+}
+
+// GVToAPD converts a govalues decimal to an apd decimal.
+// The conversion is exact.
+func GVToAPD(d gv.Decimal) *cd.Decimal {
+	exp := int32(-d.Scale())
+	if c := d.Coef(); c <= math.MaxInt64 {
+		if d.IsNeg() {
+			return cd.New(-int64(c), exp)
+		}
+		return cd.New(int64(c), exp)
+	}
+	r := cd.NewWithBigInt(new(cd.BigInt).SetUint64(d.Coef()), exp)
+	r.Negative = d.IsNeg()
+	return r
+}
+
+// APDToGV converts an apd decimal to a govalues decimal.
+// Infinities and NaNs are reported with [ErrNotFinite], and negative zeros
+// are converted to zeros.
+// See the package documentation for rounding and other errors.
+func APDToGV(d *cd.Decimal) (gv.Decimal, error) {
+	if d.Form != cd.Finite {
+		return gv.Decimal{}, ErrNotFinite
+	}
+	exp := int(d.Exponent)
+	if d.Coeff.IsUint64() && exp <= 0 && exp >= -gv.MaxScale {
+		if c := d.Coeff.Uint64(); c < 1e19 {
+			// Fast path for coefficients that fit 19 digits
+			return newGV(d.Negative && c != 0, c, -exp)
+		}
+	}
+	return fromBig(d.Negative, d.Coeff.MathBigInt(), exp)
+}
+
+// GVToRat converts a govalues decimal to a rational number.
+// The conversion is exact.
+func GVToRat(d gv.Decimal) *big.Rat {
+	scale := d.Scale()
+	if c := d.Coef(); c <= math.MaxInt64 && scale < gv.MaxScale {
+		n := int64(c)
+		if d.IsNeg() {
+			n = -n
+		}
+		return big.NewRat(n, int64(math.Pow10(scale)))
+	}
+	return new(big.Rat).SetFrac(coefBig(d), pow10(scale))
+}
+
+// RatToGV converts a rational number to a govalues decimal with the
+// smallest scale that represents it, such as 0.125 for 1/8.
+// See the package documentation for rounding and errors.
+func RatToGV(r *big.Rat) (gv.Decimal, error) {
+	num, den := new(big.Int).Abs(r.Num()), r.Denom()
+	neg := r.Sign() < 0
+	whole := new(big.Int).Quo(num, den)
+	scale := gv.MaxScale
+	if whole.Sign() != 0 {
+		n := numDigits(whole)
+		if n > gv.MaxPrec {
+			return gv.Decimal{}, ErrOverflow
+		}
+		scale = min(gv.MaxScale, gv.MaxPrec-n)
+	}
+	q, exact := roundQuo(new(big.Int).Mul(num, pow10(scale)), den)
+	d, err := fromBig(neg, q, -scale)
+	if err != nil {
+		return gv.Decimal{}, err
+	}
+	d = d.Trim(0)
+	if !exact {
+		return d, ErrInexact
+	}
+	return d, nil
+}
+
+// SSToAPD converts a shopspring decimal to an apd decimal.
+// The conversion is exact.
+func SSToAPD(d ss.Decimal) *cd.Decimal {
+	c := d.Coefficient()
+	r := cd.NewWithBigInt(new(cd.BigInt).SetMathBigInt(c.Abs(c)), d.Exponent())
+	r.Negative = d.Sign() < 0
+	return r
+}
+
+// APDToSS converts an apd decimal to a shopspring decimal.
+// Infinities and NaNs are reported with [ErrNotFinite], and negative zeros
+// are converted to zeros.
+func APDToSS(d *cd.Decimal) (ss.Decimal, error) {
+	if d.Form != cd.Finite {
+		return ss.Decimal{}, ErrNotFinite
+	}
+	c := d.Coeff.MathBigInt()
+	if d.Negative {
+		c.Neg(c)
+	}
+	return ss.NewFromBigInt(c, d.Exponent), nil
+}
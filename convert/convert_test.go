@@ -0,0 +1,478 @@
+package convert_test
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+	"testing"
+
+	cd "github.com/cockroachdb/apd/v3"
+	gv "github.com/govalues/decimal"
+	"github.com/govalues/decimal-tests/convert"
+	ss "github.com/shopspring/decimal"
+)
+
+var (
+	resultError error
+	resultGV    gv.Decimal
+	resultSS    ss.Decimal
+	resultCD    *cd.Decimal
+	resultRat   *big.Rat
+	resultStr   string
+)
+
+func TestToGV(t *testing.T) {
+	tests := []struct {
+		s       string
+		want    string
+		wantErr error
+	}{
+		{"0", "0", nil},
+		{"-0", "0", nil},
+		{"0.00", "0.00", nil},
+		{"1.50", "1.50", nil},
+		{"-123.45", "-123.45", nil},
+		{"1E+3", "1000", nil},
+		{"9223372036854775807", "9223372036854775807", nil},
+		{"-9223372036854775808", "-9223372036854775808", nil},
+		{"9999999999999999999", "9999999999999999999", nil},
+		{"-9999999999999999999", "-9999999999999999999", nil},
+		{"999999999.9999999999", "999999999.9999999999", nil},
+		{"0.1234567890123456789", "0.1234567890123456789", nil},
+		{"0.0000000000000000001", "0.0000000000000000001", nil},
+		{"1.00000000000000000000000", "1.000000000000000000", nil},
+		{"1E-30", "0.0000000000000000000", ErrInexact},
+		{"9E-20", "0.0000000000000000001", ErrInexact},
+		{"9E-21", "0.0000000000000000000", ErrInexact},
+		{"99E-21", "0.0000000000000000001", ErrInexact},
+		{"99E-22", "0.0000000000000000000", ErrInexact},
+		{"0.00000000000000000005", "0.0000000000000000000", ErrInexact},
+		{"0.00000000000000000015", "0.0000000000000000002", ErrInexact},
+		{"-0.00000000000000000015", "-0.0000000000000000002", ErrInexact},
+		{"0.99999999999999999995", "1.000000000000000000", ErrInexact},
+		{"1.23456789012345678901", "1.234567890123456789", ErrInexact},
+		{"123456789012345678.95", "123456789012345679.0", ErrInexact},
+		{"999999999999999999.95", "1000000000000000000", ErrInexact},
+		{"9999999999999999999.4", "9999999999999999999", ErrInexact},
+		{"9999999999999999999.5", "", ErrOverflow},
+		{"10000000000000000000", "", ErrOverflow},
+		{"1E+19", "", ErrOverflow},
+		{"-1E+100", "", ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			x := ss.RequireFromString(tt.s)
+			got, err := convert.SSToGV(x)
+			checkGV(t, "SSToGV", got, err, tt.want, tt.wantErr)
+
+			y, _, err := cd.NewFromString(tt.s)
+			if err != nil {
+				t.Fatalf("cd.NewFromString(%q) failed: %v", tt.s, err)
+			}
+			got, err = convert.APDToGV(y)
+			checkGV(t, "APDToGV", got, err, tt.want, tt.wantErr)
+		})
+	}
+
+	for _, s := range []string{"NaN", "sNaN", "Infinity", "-Infinity"} {
+		y, _, err := cd.NewFromString(s)
+		if err != nil {
+			t.Fatalf("cd.NewFromString(%q) failed: %v", s, err)
+		}
+		_, err = convert.APDToGV(y)
+		if !errors.Is(err, convert.ErrNotFinite) {
+			t.Errorf("APDToGV(%v) failed: %v, want %v", s, err, convert.ErrNotFinite)
+		}
+		_, err = convert.APDToSS(y)
+		if !errors.Is(err, convert.ErrNotFinite) {
+			t.Errorf("APDToSS(%v) failed: %v, want %v", s, err, convert.ErrNotFinite)
+		}
+	}
+}
+
+// TestToGV_tinyExponent checks that values far below the smallest step
+// are rounded to zero without building a huge power of ten.
+func TestToGV_tinyExponent(t *testing.T) {
+	for _, exp := range []int32{-10_000_000, math.MinInt32} {
+		got, err := convert.APDToGV(cd.New(-1, exp))
+		checkGV(t, "APDToGV", got, err, "0.0000000000000000000", ErrInexact)
+
+		got, err = convert.SSToGV(ss.New(-1, exp))
+		checkGV(t, "SSToGV", got, err, "0.0000000000000000000", ErrInexact)
+	}
+}
+
+func TestRatToGV(t *testing.T) {
+	tests := []struct {
+		r       string
+		want    string
+		wantErr error
+	}{
+		{"0", "0", nil},
+		{"1/2", "0.5", nil},
+		{"-1/8", "-0.125", nil},
+		{"150/100", "1.5", nil},
+		{"9999999999999999999", "9999999999999999999", nil},
+		{"1/10000000000000000000", "0.0000000000000000001", nil},
+		{"1/3", "0.3333333333333333333", ErrInexact},
+		{"2/3", "0.6666666666666666667", ErrInexact},
+		{"-2/3", "-0.6666666666666666667", ErrInexact},
+		{"1/1048576", "0.0000009536743164062", ErrInexact},
+		{"1000000/3", "333333.3333333333333", ErrInexact},
+		{"1/100000000000000000000", "0", ErrInexact},
+		{"19999999999999999999/2", "", ErrOverflow},
+		{"10000000000000000000", "", ErrOverflow},
+	}
+	for _, tt := range tests {
+		t.Run(tt.r, func(t *testing.T) {
+			r, ok := new(big.Rat).SetString(tt.r)
+			if !ok {
+				t.Fatalf("SetString(%q) failed", tt.r)
+			}
+			got, err := convert.RatToGV(r)
+			checkGV(t, "RatToGV", got, err, tt.want, tt.wantErr)
+		})
+	}
+}
+
+// Aliases of the errors for compact test tables.
+var (
+	ErrInexact  = convert.ErrInexact
+	ErrOverflow = convert.ErrOverflow
+)
+
+func checkGV(t *testing.T, name string, got gv.Decimal, err error, want string, wantErr error) {
+	t.Helper()
+	if !errors.Is(err, wantErr) || (err != nil && wantErr == nil) {
+		t.Errorf("%v() failed: %v, want %v", name, err, wantErr)
+		return
+	}
+	if errors.Is(err, ErrOverflow) {
+		return
+	}
+	if got.String() != want {
+		t.Errorf("%v() = %q, want %q", name, got, want)
+	}
+}
+
+func TestFromGV(t *testing.T) {
+	for _, s := range []string{
+		"0", "0.00", "1.50", "-123.45",
+		"9223372036854775807", "-9223372036854775808",
+		"9999999999999999999", "-9999999999999999999",
+		"-999999999.9999999999", "0.1234567890123456789", "-0.0000000000000000001",
+	} {
+		d := gv.MustParse(s)
+
+		x := convert.GVToSS(d)
+		if got := x.StringFixed(-x.Exponent()); got != s {
+			t.Errorf("GVToSS(%v) = %q, want %q", s, got, s)
+		}
+
+		y := convert.GVToAPD(d)
+		if got := y.Text('f'); got != s {
+			t.Errorf("GVToAPD(%v) = %q, want %q", s, got, s)
+		}
+
+		r := convert.GVToRat(d)
+		if want, _ := new(big.Rat).SetString(s); r.Cmp(want) != 0 {
+			t.Errorf("GVToRat(%v) = %v, want %v", s, r, want)
+		}
+
+		z, err := convert.APDToSS(convert.SSToAPD(x))
+		if err != nil {
+			t.Errorf("APDToSS(SSToAPD(%v)) failed: %v", s, err)
+			continue
+		}
+		if !z.Equal(x) || z.Exponent() != x.Exponent() {
+			t.Errorf("APDToSS(SSToAPD(%v)) = %v, want %v", s, z, x)
+		}
+	}
+}
+
+// newGV returns the decimal with the coefficient and scale,
+// or false if they do not fit govalues.
+func newGV(coef uint64, scale int, neg bool) (gv.Decimal, bool) {
+	if coef >= 1e19 || scale < gv.MinScale || scale > gv.MaxScale {
+		return gv.Decimal{}, false
+	}
+	s := strconv.FormatUint(coef, 10)
+	if len(s) <= scale {
+		s = strings.Repeat("0", scale-len(s)+1) + s
+	}
+	if scale > 0 {
+		s = s[:len(s)-scale] + "." + s[len(s)-scale:]
+	}
+	if neg {
+		s = "-" + s
+	}
+	return gv.MustParse(s), true
+}
+
+var corpus = []struct {
+	coef  uint64
+	scale int
+}{
+	{0, 0},
+	{0, 19},
+	{1, 0},
+	{1, 19},
+	{150, 2},
+	{12345, 2},
+	{math.MaxInt64, 0},
+	{math.MaxInt64, 19},
+	{math.MaxInt64 + 1, 0},
+	{math.MaxInt64 + 1, 18},
+	{9999999999999999999, 0},
+	{9999999999999999999, 10},
+	{9999999999999999999, 19},
+	{1000000000000000000, 18},
+}
+
+// FuzzRoundTrip checks that every govalues decimal is converted
+// to other libraries and back exactly, including its scale.
+func FuzzRoundTrip(f *testing.F) {
+	for _, d := range corpus {
+		f.Add(d.coef, d.scale, false)
+		f.Add(d.coef, d.scale, true)
+	}
+
+	f.Fuzz(func(t *testing.T, coef uint64, scale int, neg bool) {
+		d, ok := newGV(coef, scale, neg)
+		if !ok {
+			t.Skip()
+			return
+		}
+
+		x := convert.GVToSS(d)
+		got, err := convert.SSToGV(x)
+		if err != nil {
+			t.Errorf("SSToGV(GVToSS(%v)) failed: %v", d, err)
+		} else if got != d {
+			t.Errorf("SSToGV(GVToSS(%v)) = %v, want %v", d, got, d)
+		}
+
+		y := convert.GVToAPD(d)
+		got, err = convert.APDToGV(y)
+		if err != nil {
+			t.Errorf("APDToGV(GVToAPD(%v)) failed: %v", d, err)
+		} else if got != d {
+			t.Errorf("APDToGV(GVToAPD(%v)) = %v, want %v", d, got, d)
+		}
+
+		z, err := convert.APDToSS(convert.SSToAPD(x))
+		if err != nil {
+			t.Errorf("APDToSS(SSToAPD(%v)) failed: %v", x, err)
+		} else if got, err = convert.SSToGV(z); err != nil || got != d {
+			t.Errorf("SSToGV(APDToSS(SSToAPD(%v))) = %v, %v, want %v", d, got, err, d)
+		}
+
+		// Rationals have no scale
+		r := convert.GVToRat(d)
+		got, err = convert.RatToGV(r)
+		if err != nil {
+			t.Errorf("RatToGV(GVToRat(%v)) failed: %v", d, err)
+		} else if want := d.Trim(0); got != want {
+			t.Errorf("RatToGV(GVToRat(%v)) = %v, want %v", d, got, want)
+		}
+	})
+}
+
+// maxGV is the smallest value that overflows after rounding.
+var maxGV = ss.RequireFromString("9999999999999999999.5")
+
+// FuzzToGV checks conversions of values with up to 38 digits,
+// which are exact, rounded half to even, or overflow.
+func FuzzToGV(f *testing.F) {
+	f.Add(int64(0), int64(1), int32(0))
+	f.Add(int64(1), int64(0), int32(-19))
+	f.Add(int64(1), int64(5), int32(-20))
+	f.Add(int64(-1), int64(-5), int32(-20))
+	f.Add(int64(9), int64(999999999999999995), int32(-20))
+	f.Add(int64(9), int64(999999999999999995), int32(-1))
+	f.Add(int64(0), int64(5), int32(-40))
+	f.Add(int64(0), int64(1), int32(19))
+	f.Add(int64(math.MaxInt64), int64(math.MaxInt64), int32(-38))
+
+	f.Fuzz(func(t *testing.T, hi, lo int64, exp int32) {
+		if exp < -60 || exp > 40 || (hi < 0) != (lo < 0) && hi != 0 && lo != 0 {
+			t.Skip()
+			return
+		}
+		c := new(big.Int).Mul(big.NewInt(hi), big.NewInt(1e18))
+		c.Mul(c, big.NewInt(10))
+		c.Add(c, big.NewInt(lo))
+		x := ss.NewFromBigInt(c, exp)
+
+		got, err := convert.SSToGV(x)
+		switch {
+		case err == nil:
+			if !convert.GVToSS(got).Equal(x) {
+				t.Errorf("SSToGV(%v) = %v, want exact", x, got)
+			}
+		case errors.Is(err, convert.ErrInexact):
+			want := x.RoundBank(int32(got.Scale()))
+			if !convert.GVToSS(got).Equal(want) || want.Equal(x) {
+				t.Errorf("SSToGV(%v) = %v, want %v rounded", x, got, want)
+			}
+			if got.Scale() != gv.MaxScale && got.Prec() != gv.MaxPrec {
+				t.Errorf("SSToGV(%v) = %v, want 19 digits", x, got)
+			}
+		case errors.Is(err, convert.ErrOverflow):
+			if x.Abs().LessThan(maxGV) {
+				t.Errorf("SSToGV(%v) overflows, want no overflow", x)
+			}
+		default:
+			t.Errorf("SSToGV(%v) failed: %v", x, err)
+		}
+		if !errors.Is(err, convert.ErrOverflow) && !x.Abs().LessThan(maxGV) {
+			t.Errorf("SSToGV(%v) = %v, want overflow", x, got)
+		}
+
+		// Same result and error from apd
+		gotCD, errCD := convert.APDToGV(convert.SSToAPD(x))
+		if gotCD != got || !errors.Is(errCD, err) {
+			t.Errorf("APDToGV(%v) = %v, %v, want %v, %v", x, gotCD, errCD, got, err)
+		}
+	})
+}
+
+// FuzzRatToGV checks that fractions are converted exactly, rounded
+// to 19 digits with an error of at most half a unit, or overflow.
+func FuzzRatToGV(f *testing.F) {
+	f.Add(int64(1), int64(3))
+	f.Add(int64(-2), int64(3))
+	f.Add(int64(1), int64(1048576))
+	f.Add(int64(math.MaxInt64), int64(1))
+	f.Add(int64(math.MaxInt64), int64(7))
+	f.Add(int64(math.MinInt64), int64(-1))
+
+	f.Fuzz(func(t *testing.T, num, den int64) {
+		if den == 0 {
+			t.Skip()
+			return
+		}
+		r := new(big.Rat).SetFrac(big.NewInt(num), big.NewInt(den))
+
+		got, err := convert.RatToGV(r)
+		if err != nil && !errors.Is(err, convert.ErrInexact) {
+			// int64 fractions fit 19 digits
+			t.Errorf("RatToGV(%v) failed: %v", r, err)
+			return
+		}
+		if got != got.Trim(0) {
+			t.Errorf("RatToGV(%v) = %v, want trailing zeros trimmed", r, got)
+		}
+
+		diff := new(big.Rat).Sub(convert.GVToRat(got), r)
+		if (diff.Sign() == 0) != (err == nil) {
+			t.Errorf("RatToGV(%v) = %v, %v, want error if and only if inexact", r, got, err)
+		}
+
+		// Unit in the last place of 19 digits
+		scale := gv.MaxScale
+		if w := new(big.Int).Quo(r.Num(), r.Denom()); w.Sign() != 0 {
+			scale = min(gv.MaxScale, gv.MaxPrec-len(w.Abs(w).Text(10)))
+		}
+		half := new(big.Rat).SetFrac(big.NewInt(1), new(big.Int).Mul(big.NewInt(2), pow10(scale)))
+		if diff.Abs(diff).Cmp(half) > 0 {
+			t.Errorf("RatToGV(%v) = %v, want error at most %v", r, got, half)
+		}
+	})
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+var sizes = map[string]string{
+	"size=small":    "5",
+	"size=money":    "12345.67",
+	"size=full":     "1234567890123456789",
+	"size=maxscale": "0.1234567890123456789",
+}
+
+func BenchmarkConvert_FromGV(b *testing.B) {
+	for name, s := range sizes {
+		d := gv.MustParse(s)
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=shopspring", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultSS = convert.GVToSS(d)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultCD = convert.GVToAPD(d)
+				}
+			})
+
+			b.Run("mod=rat", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultRat = convert.GVToRat(d)
+				}
+			})
+
+			// Formatting, which conversions through strings start with,
+			// is the baseline of the comparison.
+			b.Run("mod=govalues", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultStr = d.String()
+				}
+			})
+		})
+	}
+}
+
+func BenchmarkConvert_ToGV(b *testing.B) {
+	tests := map[string]string{
+		// 30 digits, which are rounded
+		"size=long": "12345678901.2345678901234567890",
+	}
+	for name, s := range sizes {
+		tests[name] = s
+	}
+	for name, s := range tests {
+		x := ss.RequireFromString(s)
+		y, _, _ := cd.NewFromString(s)
+		r, _ := new(big.Rat).SetString(s)
+		b.Run(name, func(b *testing.B) {
+			b.Run("mod=shopspring", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultGV, resultError = convert.SSToGV(x)
+				}
+			})
+
+			b.Run("mod=cockroachdb", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultGV, resultError = convert.APDToGV(y)
+				}
+			})
+
+			b.Run("mod=rat", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultGV, resultError = convert.RatToGV(r)
+				}
+			})
+
+			// Parsing, which conversions through strings end with,
+			// is the baseline of the comparison.
+			b.Run("mod=govalues", func(b *testing.B) {
+				b.ReportAllocs()
+				for range b.N {
+					resultGV, resultError = gv.Parse(s)
+				}
+			})
+		})
+	}
+}
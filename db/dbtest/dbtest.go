@@ -0,0 +1,372 @@
+// Package dbtest runs the same decimal tests against every database.
+//
+// A database is described by a [Dialect], which provides the connection,
+// the column types, the placeholder style, and whether inserted rows are
+// returned by INSERT ... RETURNING or read back by LastInsertId.
+// Every row of [Cases] is inserted into the columns that the dialect
+// supports and scanned back into decimals.
+package dbtest
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/govalues/decimal"
+	"github.com/jmoiron/sqlx"
+)
+
+// Dialect describes a database/sql database.
+type Dialect interface {
+	// Driver returns the name of the database/sql driver.
+	Driver() string
+	// DSN returns the default data source name, such as the one
+	// of the server in docker-compose.yml.
+	DSN() string
+	// Env returns the environment variable that overrides DSN, or an empty
+	// string for databases that are always available, such as in-memory
+	// SQLite.
+	Env() string
+	// ColumnType returns the SQL type of the column,
+	// or an empty string if the database does not have the type.
+	ColumnType(k Kind) string
+	// Placeholder returns the placeholder of the n-th parameter,
+	// starting from 1, such as "$1" or "?".
+	Placeholder(n int) string
+	// Returning reports whether INSERT ... RETURNING is supported.
+	// If not, inserted rows are read back by the id from LastInsertId.
+	Returning() bool
+	// IDColumn returns the definition of an auto-increment primary key
+	// named id, such as "id INT PRIMARY KEY AUTO_INCREMENT".
+	// It is used only if Returning is false.
+	IDColumn() string
+}
+
+// Kind is a column of the case table.
+type Kind int
+
+const (
+	Int16 Kind = iota
+	Int32
+	Int64
+	Float32
+	Float64
+	String
+	Decimal // DECIMAL without precision limits, or Decimal128 in MongoDB
+	Decimal10_0
+	Decimal19_2
+	Decimal19_0
+	numKinds
+)
+
+var kindNames = [...]string{
+	Int16:       "int16",
+	Int32:       "int32",
+	Int64:       "int64",
+	Float32:     "float32",
+	Float64:     "float64",
+	String:      "string",
+	Decimal:     "decimal",
+	Decimal10_0: "decimal10_0",
+	Decimal19_2: "decimal19_2",
+	Decimal19_0: "decimal19_0",
+}
+
+// Column returns the name of the column, such as "decimal19_2".
+func (k Kind) Column() string {
+	return kindNames[k]
+}
+
+func (k Kind) String() string {
+	return k.Column()
+}
+
+// Value is an inserted value and the decimal that is expected back.
+type Value struct {
+	In   any
+	Want string
+}
+
+// Case is a row of the case table.
+type Case struct {
+	Name   string
+	Values [numKinds]Value
+}
+
+// Cases are the rows inserted into every database.
+// Float columns keep 6 or 15 significant digits, and DECIMAL columns
+// round to their scale.
+var Cases = []Case{
+	{
+		"Largest negative values",
+		[numKinds]Value{
+			Int16:       {int16(math.MinInt16), "-32768"},
+			Int32:       {int32(math.MinInt32), "-2147483648"},
+			Int64:       {int64(math.MinInt64), "-9223372036854775808"},
+			Float32:     {float32(-9.9999999e+18), "-9999999980506448000"},
+			Float64:     {float64(-9.99999999999999e+18), "-9999999999999990000"},
+			String:      {"-9999999999999999999", "-9999999999999999999"},
+			Decimal:     {decimal.MustParse("-9999999999999999999"), "-9999999999999999999"},
+			Decimal10_0: {decimal.MustParse("-9999999999"), "-9999999999"},
+			Decimal19_2: {decimal.MustParse("-99999999999999999.99"), "-99999999999999999.99"},
+			Decimal19_0: {decimal.MustParse("-9999999999999999999"), "-9999999999999999999"},
+		},
+	},
+	{
+		"Smallest negative values",
+		[numKinds]Value{
+			Int16:       {int16(-1), "-1"},
+			Int32:       {int32(-1), "-1"},
+			Int64:       {int64(-1), "-1"},
+			Float32:     {float32(-math.SmallestNonzeroFloat32), "0.0000000000000000000"},
+			Float64:     {float64(-math.SmallestNonzeroFloat64), "0.0000000000000000000"},
+			String:      {"-0.0000000000000000001", "-0.0000000000000000001"},
+			Decimal:     {decimal.MustParse("-0.0000000000000000001"), "-0.0000000000000000001"},
+			Decimal10_0: {decimal.MustParse("-0.0000000000000000001"), "0"},
+			Decimal19_2: {decimal.MustParse("-0.0000000000000000001"), "0.00"},
+			Decimal19_0: {decimal.MustParse("-0.0000000000000000001"), "0"},
+		},
+	},
+	{
+		"Zero",
+		[numKinds]Value{
+			Int16:       {int16(0), "0"},
+			Int32:       {int32(0), "0"},
+			Int64:       {int64(0), "0"},
+			Float32:     {float32(0), "0"},
+			Float64:     {float64(0), "0"},
+			String:      {"0.0000000000000000000", "0.0000000000000000000"},
+			Decimal:     {decimal.MustParse("0.0000000000000000000"), "0.0000000000000000000"},
+			Decimal10_0: {decimal.MustParse("0.0000000000000000000"), "0"},
+			Decimal19_2: {decimal.MustParse("0.0000000000000000000"), "0.00"},
+			Decimal19_0: {decimal.MustParse("0.0000000000000000000"), "0"},
+		},
+	},
+	{
+		"Pi",
+		[numKinds]Value{
+			Int16:       {int16(3), "3"},
+			Int32:       {int32(3), "3"},
+			Int64:       {int64(3), "3"},
+			Float32:     {float32(math.Pi), "3.1415927410125732"},
+			Float64:     {float64(math.Pi), "3.141592653589793"},
+			String:      {decimal.Pi.String(), "3.141592653589793238"},
+			Decimal:     {decimal.Pi, "3.141592653589793238"},
+			Decimal10_0: {decimal.MustParse("3"), "3"},
+			Decimal19_2: {decimal.Pi, "3.14"},
+			Decimal19_0: {decimal.Pi, "3"},
+		},
+	},
+	{
+		"Smallest positive values",
+		[numKinds]Value{
+			Int16:       {int16(1), "1"},
+			Int32:       {int32(1), "1"},
+			Int64:       {int64(1), "1"},
+			Float32:     {float32(math.SmallestNonzeroFloat32), "0.0000000000000000000"},
+			Float64:     {float64(math.SmallestNonzeroFloat64), "0.0000000000000000000"},
+			String:      {"0.0000000000000000001", "0.0000000000000000001"},
+			Decimal:     {decimal.MustParse("0.0000000000000000001"), "0.0000000000000000001"},
+			Decimal10_0: {decimal.MustParse("0.0000000000000000001"), "0"},
+			Decimal19_2: {decimal.MustParse("0.0000000000000000001"), "0.00"},
+			Decimal19_0: {decimal.MustParse("0.0000000000000000001"), "0"},
+		},
+	},
+	{
+		"Largest positive values",
+		[numKinds]Value{
+			Int16:       {int16(math.MaxInt16), "32767"},
+			Int32:       {int32(math.MaxInt32), "2147483647"},
+			Int64:       {int64(math.MaxInt64), "9223372036854775807"},
+			Float32:     {float32(9.999999e+18), "9999998880994820000"},
+			Float64:     {float64(9.99999999999999e+18), "9999999999999990000"},
+			String:      {"9999999999999999999", "9999999999999999999"},
+			Decimal:     {decimal.MustParse("9999999999999999999"), "9999999999999999999"},
+			Decimal10_0: {decimal.MustParse("9999999999"), "9999999999"},
+			Decimal19_2: {decimal.MustParse("99999999999999999.99"), "99999999999999999.99"},
+			Decimal19_0: {decimal.MustParse("9999999999999999999"), "9999999999999999999"},
+		},
+	},
+}
+
+// LookupDSN returns the data source name from the environment variable,
+// or the default one.
+// It reports whether the variable is set, in which case the database must be
+// reachable and tests fail instead of being skipped.
+func LookupDSN(env, def string) (dsn string, strict bool) {
+	if env == "" {
+		return def, true
+	}
+	if dsn := os.Getenv(env); dsn != "" {
+		return dsn, true
+	}
+	return def, false
+}
+
+// DB is a connection to the database of a dialect.
+// The connection is opened by [DB.Main].
+type DB struct {
+	*sqlx.DB
+	Dialect Dialect
+	skip    string
+}
+
+// New returns an unconnected database of the dialect.
+func New(d Dialect) *DB {
+	return &DB{Dialect: d}
+}
+
+// Main connects to the database, runs the tests, and returns the exit code
+// for os.Exit.
+// If the database is not reachable at the default data source name,
+// the tests are skipped, so that "go test ./..." passes without servers.
+func (db *DB) Main(m *testing.M) int {
+	dsn, strict := LookupDSN(db.Dialect.Env(), db.Dialect.DSN())
+	var err error
+	db.DB, err = sqlx.Connect(db.Dialect.Driver(), dsn)
+	if err != nil {
+		if strict {
+			log.Printf("Connect(%q) failed: %v\n", dsn, err)
+			return 1
+		}
+		db.skip = SkipReason(db.Dialect.Env(), dsn, err)
+		return m.Run()
+	}
+	defer db.Close()
+	return m.Run()
+}
+
+// SkipReason returns the message of tests skipped because the database
+// is not reachable.
+func SkipReason(env, dsn string, err error) string {
+	return fmt.Sprintf("database is not reachable at %q: %v\n"+
+		"Run \"docker compose up\" in the db directory, or set %v to the data source name of a server.", dsn, err, env)
+}
+
+// SkipIfAbsent skips the test if the database is not reachable.
+func (db *DB) SkipIfAbsent(t *testing.T) {
+	t.Helper()
+	if db.skip != "" {
+		t.Skip(db.skip)
+	}
+}
+
+// kinds returns the columns supported by the dialect.
+func (db *DB) kinds() []Kind {
+	var kinds []Kind
+	for k := range numKinds {
+		if db.Dialect.ColumnType(k) != "" {
+			kinds = append(kinds, k)
+		}
+	}
+	return kinds
+}
+
+// placeholders returns the placeholders of n parameters.
+func (db *DB) placeholders(n int) string {
+	p := make([]string, n)
+	for i := range p {
+		p[i] = db.Dialect.Placeholder(i + 1)
+	}
+	return strings.Join(p, ", ")
+}
+
+// TestInsert inserts every case into a table with a column of every
+// supported type and checks the scanned decimals.
+func (db *DB) TestInsert(t *testing.T) {
+	t.Helper()
+	db.SkipIfAbsent(t)
+	kinds := db.kinds()
+	defs := make([]string, 0, len(kinds)+1)
+	cols := make([]string, 0, len(kinds))
+	if !db.Dialect.Returning() {
+		defs = append(defs, db.Dialect.IDColumn())
+	}
+	for _, k := range kinds {
+		defs = append(defs, k.Column()+" "+db.Dialect.ColumnType(k))
+		cols = append(cols, k.Column())
+	}
+
+	createTable := fmt.Sprintf("CREATE TABLE IF NOT EXISTS decimal_insert (\n\t%v\n)", strings.Join(defs, ",\n\t"))
+	_, err := db.Exec(createTable)
+	if err != nil {
+		t.Fatalf("Exec(%q) failed: %v", createTable, err)
+	}
+
+	list := strings.Join(cols, ", ")
+	insert := fmt.Sprintf("INSERT INTO decimal_insert (%v) VALUES (%v)", list, db.placeholders(len(cols)))
+	query := fmt.Sprintf("SELECT %v FROM decimal_insert WHERE id = %v", list, db.Dialect.Placeholder(1))
+
+	for _, tt := range Cases {
+		t.Run(tt.Name, func(t *testing.T) {
+			args := make([]any, len(kinds))
+			for i, k := range kinds {
+				args[i] = tt.Values[k].In
+			}
+			got := make([]decimal.Decimal, len(kinds))
+			dest := make([]any, len(kinds))
+			for i := range got {
+				dest[i] = &got[i]
+			}
+
+			if db.Dialect.Returning() {
+				err := db.QueryRowx(insert+" RETURNING "+list, args...).Scan(dest...)
+				if err != nil {
+					t.Fatalf("Scan failed: %v", err)
+				}
+			} else {
+				result, err := db.Exec(insert, args...)
+				if err != nil {
+					t.Fatalf("Exec failed: %v", err)
+				}
+				id, err := result.LastInsertId()
+				if err != nil {
+					t.Fatalf("LastInsertId failed: %v", err)
+				}
+				err = db.QueryRowx(query, id).Scan(dest...)
+				if err != nil {
+					t.Fatalf("Scan failed: %v", err)
+				}
+			}
+
+			for i, k := range kinds {
+				if want := decimal.MustParse(tt.Values[k].Want); got[i] != want {
+					t.Errorf("%v = %v, want %v", k, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+const selectNull = "SELECT NULL"
+
+// TestDecimalNull checks that scanning NULL into a decimal fails.
+func (db *DB) TestDecimalNull(t *testing.T) {
+	t.Helper()
+	db.SkipIfAbsent(t)
+	var got decimal.Decimal
+	err := db.QueryRowx(selectNull).Scan(&got)
+	if err == nil {
+		t.Errorf("QueryRowx(%q) did not fail, got %v", selectNull, got)
+	}
+}
+
+// TestNullDecimalNull checks that NULL is scanned into an invalid
+// null decimal.
+func (db *DB) TestNullDecimalNull(t *testing.T) {
+	t.Helper()
+	db.SkipIfAbsent(t)
+	var got decimal.NullDecimal
+	err := db.QueryRowx(selectNull).Scan(&got)
+	if err != nil {
+		t.Errorf("QueryRowx(%q) failed: %v", selectNull, err)
+		return
+	}
+	want := decimal.NullDecimal{}
+	if got != want {
+		t.Errorf("Scan() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,180 @@
+// Package fakedriver is an in-process database/sql driver for testing
+// the driver.Valuer and sql.Scanner implementations without a server.
+//
+// The driver records every statement with its parameters, as converted by
+// database/sql for drivers that do not implement driver.NamedValueChecker,
+// and returns scripted rows to queries:
+//
+//	d := fakedriver.New()
+//	db := sql.OpenDB(d)
+//	_, err := db.Exec("INSERT", decimal.MustParse("1.50"))
+//	// d.Calls()[0].Args[0] is the string "1.50"
+//
+//	d.Script([]driver.Value{int64(5)})
+//	err = db.QueryRow("SELECT").Scan(&got)
+//	// got is 5
+package fakedriver
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"slices"
+	"sync"
+)
+
+// Call is a recorded statement.
+type Call struct {
+	Query string
+	Args  []driver.Value
+}
+
+// Driver records statements and returns scripted rows.
+// It implements driver.Connector, so it is used with sql.OpenDB
+// and does not need to be registered.
+type Driver struct {
+	mu    sync.Mutex
+	calls []Call
+	rows  [][]driver.Value
+}
+
+// New returns a driver without scripted rows.
+func New() *Driver {
+	return &Driver{}
+}
+
+// Script sets the rows returned by the next query.
+// Every row must have the same number of columns.
+func (d *Driver) Script(rows ...[]driver.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.rows = rows
+}
+
+// Calls returns the recorded statements in the order of execution.
+func (d *Driver) Calls() []Call {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.calls)
+}
+
+// Reset forgets the recorded statements and the scripted rows.
+func (d *Driver) Reset() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls = nil
+	d.rows = nil
+}
+
+func (d *Driver) record(query string, args []driver.Value) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.calls = append(d.calls, Call{query, slices.Clone(args)})
+}
+
+// take returns the scripted rows and forgets them.
+func (d *Driver) take() [][]driver.Value {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	rows := d.rows
+	d.rows = nil
+	return rows
+}
+
+// Connect implements the driver.Connector interface.
+func (d *Driver) Connect(context.Context) (driver.Conn, error) {
+	return &conn{d}, nil
+}
+
+// Driver implements the driver.Connector interface.
+func (d *Driver) Driver() driver.Driver {
+	return d
+}
+
+// Open implements the driver.Driver interface.
+// The name is ignored.
+func (d *Driver) Open(string) (driver.Conn, error) {
+	return &conn{d}, nil
+}
+
+type conn struct {
+	d *Driver
+}
+
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return &stmt{c.d, query}, nil
+}
+
+func (c *conn) Close() error {
+	return nil
+}
+
+func (c *conn) Begin() (driver.Tx, error) {
+	return tx{}, nil
+}
+
+type tx struct{}
+
+func (tx) Commit() error {
+	return nil
+}
+
+func (tx) Rollback() error {
+	return nil
+}
+
+type stmt struct {
+	d     *Driver
+	query string
+}
+
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput returns -1, so that database/sql does not check
+// the number of parameters.
+func (s *stmt) NumInput() int {
+	return -1
+}
+
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.d.record(s.query, args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.record(s.query, args)
+	return &rows{values: s.d.take()}, nil
+}
+
+type rows struct {
+	values [][]driver.Value
+	next   int
+}
+
+// Columns returns the names c1, c2, and so on.
+func (r *rows) Columns() []string {
+	if len(r.values) == 0 {
+		return nil
+	}
+	cols := make([]string, len(r.values[0]))
+	for i := range cols {
+		cols[i] = fmt.Sprintf("c%v", i+1)
+	}
+	return cols
+}
+
+func (r *rows) Close() error {
+	return nil
+}
+
+func (r *rows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
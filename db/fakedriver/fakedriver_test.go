@@ -0,0 +1,242 @@
+package fakedriver_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/govalues/decimal"
+	"github.com/govalues/decimal-tests/db/fakedriver"
+)
+
+func open(t *testing.T) (*fakedriver.Driver, *sql.DB) {
+	t.Helper()
+	d := fakedriver.New()
+	db := sql.OpenDB(d)
+	t.Cleanup(func() { db.Close() })
+	return d, db
+}
+
+func TestDecimal_Value(t *testing.T) {
+	var nilDecimal *decimal.Decimal
+	tests := []struct {
+		arg  any
+		want driver.Value
+	}{
+		{decimal.MustParse("0"), "0"},
+		{decimal.MustParse("0.00"), "0.00"},
+		{decimal.MustParse("1.50"), "1.50"},
+		{decimal.MustParse("-0.0000000000000000001"), "-0.0000000000000000001"},
+		{decimal.MustParse("9999999999999999999"), "9999999999999999999"},
+		{decimal.MustParse("-9999999999999999999"), "-9999999999999999999"},
+		{decimal.Pi, "3.141592653589793238"},
+		{ptr(decimal.MustParse("1.50")), "1.50"},
+		{nilDecimal, nil},
+	}
+	d, db := open(t)
+	for _, tt := range tests {
+		d.Reset()
+		_, err := db.Exec("INSERT", tt.arg)
+		if err != nil {
+			t.Errorf("Exec(%v) failed: %v", tt.arg, err)
+			continue
+		}
+		calls := d.Calls()
+		if len(calls) != 1 || len(calls[0].Args) != 1 {
+			t.Errorf("Exec(%v) recorded %v, want 1 call with 1 argument", tt.arg, calls)
+			continue
+		}
+		// The exact type matters: drivers bind strings as text
+		if got := calls[0].Args[0]; got != tt.want {
+			t.Errorf("Exec(%v) bound %T %v, want %T %v", tt.arg, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+func TestNullDecimal_Value(t *testing.T) {
+	var nilNull *decimal.NullDecimal
+	tests := []struct {
+		arg  any
+		want driver.Value
+	}{
+		{decimal.NullDecimal{}, nil},
+		{decimal.NullDecimal{Decimal: decimal.MustParse("1.50")}, nil},
+		{decimal.NullDecimal{Decimal: decimal.MustParse("1.50"), Valid: true}, "1.50"},
+		{decimal.NullDecimal{Valid: true}, "0"},
+		{ptr(decimal.NullDecimal{Decimal: decimal.MustParse("-0.01"), Valid: true}), "-0.01"},
+		{nilNull, nil},
+	}
+	d, db := open(t)
+	for _, tt := range tests {
+		d.Reset()
+		_, err := db.Exec("INSERT", tt.arg)
+		if err != nil {
+			t.Errorf("Exec(%v) failed: %v", tt.arg, err)
+			continue
+		}
+		if got := d.Calls()[0].Args[0]; got != tt.want {
+			t.Errorf("Exec(%v) bound %T %v, want %T %v", tt.arg, got, got, tt.want, tt.want)
+		}
+	}
+}
+
+func TestDecimal_ValueQuery(t *testing.T) {
+	d, db := open(t)
+	x := decimal.MustParse("12.30")
+	y := decimal.NullDecimal{}
+	rows, err := db.Query("SELECT WHERE", x, y, int64(1))
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	rows.Close()
+	calls := d.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("Query() recorded %v calls, want 1", len(calls))
+	}
+	want := []driver.Value{"12.30", nil, int64(1)}
+	for i, got := range calls[0].Args {
+		if got != want[i] {
+			t.Errorf("Query() bound %T %v, want %T %v", got, got, want[i], want[i])
+		}
+	}
+	if calls[0].Query != "SELECT WHERE" {
+		t.Errorf("Query() recorded %q, want %q", calls[0].Query, "SELECT WHERE")
+	}
+}
+
+// scanTests are the values that drivers return, and the decimals
+// that they are scanned into.
+var scanTests = []struct {
+	src     driver.Value
+	want    string
+	wantErr bool
+}{
+	{"1.50", "1.50", false},
+	{"-0.0000000000000000001", "-0.0000000000000000001", false},
+	{"9999999999999999999", "9999999999999999999", false},
+	{"1e3", "1000", false},
+	{"10000000000000000000", "", true},
+	{"", "", true},
+	{"abc", "", true},
+	{[]byte("-12.345"), "-12.345", false},
+	{[]byte("1,5"), "", true},
+	{int64(0), "0", false},
+	{int64(math.MinInt64), "-9223372036854775808", false},
+	{int64(math.MaxInt64), "9223372036854775807", false},
+	{float64(0.1), "0.1", false},
+	{float64(math.Pi), "3.141592653589793", false},
+	{float64(-9.99999999999999e+18), "-9999999999999990000", false},
+	{float64(1e19), "", true},
+	{math.NaN(), "", true},
+	{math.Inf(1), "", true},
+	{true, "", true},
+	{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "", true},
+}
+
+func TestDecimal_Scan(t *testing.T) {
+	d, db := open(t)
+	for _, tt := range scanTests {
+		d.Script([]driver.Value{tt.src})
+		var got decimal.Decimal
+		err := db.QueryRow("SELECT").Scan(&got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%T %v) did not fail, got %v", tt.src, tt.src, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%T %v) failed: %v", tt.src, tt.src, err)
+			continue
+		}
+		if want := decimal.MustParse(tt.want); got != want {
+			t.Errorf("Scan(%T %v) = %v, want %v", tt.src, tt.src, got, want)
+		}
+	}
+
+	d.Script([]driver.Value{nil})
+	var got decimal.Decimal
+	err := db.QueryRow("SELECT").Scan(&got)
+	if err == nil {
+		t.Errorf("Scan(nil) did not fail, got %v", got)
+	}
+}
+
+func TestNullDecimal_Scan(t *testing.T) {
+	d, db := open(t)
+	for _, tt := range scanTests {
+		d.Script([]driver.Value{tt.src})
+		var got decimal.NullDecimal
+		err := db.QueryRow("SELECT").Scan(&got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("Scan(%T %v) did not fail, got %v", tt.src, tt.src, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Scan(%T %v) failed: %v", tt.src, tt.src, err)
+			continue
+		}
+		want := decimal.NullDecimal{Decimal: decimal.MustParse(tt.want), Valid: true}
+		if got != want {
+			t.Errorf("Scan(%T %v) = %v, want %v", tt.src, tt.src, got, want)
+		}
+	}
+
+	// A previous value is cleared by NULL
+	d.Script([]driver.Value{nil})
+	got := decimal.NullDecimal{Decimal: decimal.MustParse("1.50"), Valid: true}
+	err := db.QueryRow("SELECT").Scan(&got)
+	if err != nil {
+		t.Errorf("Scan(nil) failed: %v", err)
+	}
+	if want := (decimal.NullDecimal{}); got != want {
+		t.Errorf("Scan(nil) = %v, want %v", got, want)
+	}
+}
+
+func TestDecimal_ScanRows(t *testing.T) {
+	d, db := open(t)
+	d.Script(
+		[]driver.Value{int64(1), "10.00", nil},
+		[]driver.Value{int64(2), []byte("0.5"), "0.25"},
+	)
+	rows, err := db.Query("SELECT")
+	if err != nil {
+		t.Fatalf("Query() failed: %v", err)
+	}
+	defer rows.Close()
+	var got []string
+	for rows.Next() {
+		var (
+			id int64
+			x  decimal.Decimal
+			y  decimal.NullDecimal
+		)
+		err = rows.Scan(&id, &x, &y)
+		if err != nil {
+			t.Fatalf("Scan() failed: %v", err)
+		}
+		got = append(got, x.String(), y.Decimal.String())
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatalf("Next() failed: %v", err)
+	}
+	want := []string{"10.00", "0", "0.5", "0.25"}
+	if len(got) != len(want) {
+		t.Fatalf("Scan() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Scan() = %v, want %v", got, want)
+			break
+		}
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
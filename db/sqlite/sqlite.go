@@ -0,0 +1,211 @@
+// Package sqlite registers exact decimal functions and a decimal
+// collation with the modernc.org/sqlite driver.
+//
+// SQLite has no decimal type and adds numbers as float64, so the functions
+// operate on decimals stored as TEXT, which are parsed with [decimal.Parse]
+// and returned as TEXT:
+//
+//	dec_add(x, y)    x + y
+//	dec_sub(x, y)    x - y
+//	dec_mul(x, y)    x * y
+//	dec_div(x, y)    x / y
+//	dec_round(x, n)  x rounded half to even to n digits after the decimal point
+//	dec_cmp(x, y)    -1, 0, or +1 as an INTEGER
+//	dec_sum(x)       the sum of the rows, also as a window function
+//	dec_avg(x)       the mean of the rows, also as a window function
+//
+// INTEGER arguments are converted exactly. REAL arguments are rejected,
+// because they have already lost precision.
+// Like the built-in functions, the scalar functions return NULL if an
+// argument is NULL, and the aggregate functions skip NULL rows and return
+// NULL if there are no other rows.
+// Invalid decimals and overflows are reported as errors.
+//
+// The decimal collation orders TEXT by value, so that "9.50" < "10.00"
+// and "1.0" = "1.00", in ORDER BY, MIN, MAX, comparisons, and indexes:
+//
+//	CREATE TABLE t (amount TEXT COLLATE decimal)
+//
+// Text that is not a decimal is ordered after all decimals, as strings.
+package sqlite
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/govalues/decimal"
+	modernc "modernc.org/sqlite"
+)
+
+// Register registers the functions and the collation with the driver.
+// It can be called more than once; they are available to the connections
+// that are opened after the first call.
+func Register() error {
+	return register()
+}
+
+var register = sync.OnceValue(func() error {
+	funcs := []struct {
+		name string
+		impl *modernc.FunctionImpl
+	}{
+		{"dec_add", binary(decimal.Decimal.Add)},
+		{"dec_sub", binary(decimal.Decimal.Sub)},
+		{"dec_mul", binary(decimal.Decimal.Mul)},
+		{"dec_div", binary(decimal.Decimal.Quo)},
+		{"dec_round", scalar(2, round)},
+		{"dec_cmp", scalar(2, cmp)},
+		{"dec_sum", aggregate(false)},
+		{"dec_avg", aggregate(true)},
+	}
+	for _, f := range funcs {
+		err := modernc.RegisterFunction(f.name, f.impl)
+		if err != nil {
+			return fmt.Errorf("registering %v: %w", f.name, err)
+		}
+	}
+	err := modernc.RegisterCollationUtf8("decimal", collate)
+	if err != nil {
+		return fmt.Errorf("registering decimal collation: %w", err)
+	}
+	return nil
+})
+
+// collate compares two TEXT values by their decimal values.
+// Invalid decimals are ordered after valid ones, so that the order is total.
+func collate(a, b string) int {
+	x, errx := decimal.Parse(a)
+	y, erry := decimal.Parse(b)
+	switch {
+	case errx == nil && erry == nil:
+		return x.Cmp(y)
+	case errx == nil:
+		return -1
+	case erry == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+// parse converts an argument to a decimal.
+func parse(v driver.Value) (decimal.Decimal, error) {
+	switch v := v.(type) {
+	case string:
+		return decimal.Parse(v)
+	case []byte:
+		return decimal.Parse(string(v))
+	case int64:
+		return decimal.New(v, 0)
+	case float64:
+		return decimal.Decimal{}, fmt.Errorf("REAL %v is not exact, store decimals as TEXT", v)
+	}
+	return decimal.Decimal{}, fmt.Errorf("type %T is not supported", v)
+}
+
+// scalar returns a deterministic function with n decimal arguments
+// that returns NULL if any of them is NULL.
+func scalar(n int32, f func([]decimal.Decimal, []driver.Value) (driver.Value, error)) *modernc.FunctionImpl {
+	return &modernc.FunctionImpl{
+		NArgs:         n,
+		Deterministic: true,
+		Scalar: func(_ *modernc.FunctionContext, args []driver.Value) (driver.Value, error) {
+			d := make([]decimal.Decimal, len(args))
+			for i, v := range args {
+				if v == nil {
+					return nil, nil
+				}
+				var err error
+				d[i], err = parse(v)
+				if err != nil {
+					return nil, err
+				}
+			}
+			return f(d, args)
+		},
+	}
+}
+
+// binary returns a scalar function that applies op to two decimals.
+func binary(op func(decimal.Decimal, decimal.Decimal) (decimal.Decimal, error)) *modernc.FunctionImpl {
+	return scalar(2, func(d []decimal.Decimal, _ []driver.Value) (driver.Value, error) {
+		r, err := op(d[0], d[1])
+		if err != nil {
+			return nil, err
+		}
+		return r.String(), nil
+	})
+}
+
+func round(d []decimal.Decimal, args []driver.Value) (driver.Value, error) {
+	scale, ok := args[1].(int64)
+	if !ok || scale < 0 || scale > decimal.MaxScale {
+		return nil, fmt.Errorf("scale must be an INTEGER between 0 and %v, got %v", decimal.MaxScale, args[1])
+	}
+	return d[0].Round(int(scale)).String(), nil
+}
+
+func cmp(d []decimal.Decimal, _ []driver.Value) (driver.Value, error) {
+	return int64(d[0].Cmp(d[1])), nil
+}
+
+// aggregate returns dec_sum, or dec_avg if avg is true.
+func aggregate(avg bool) *modernc.FunctionImpl {
+	return &modernc.FunctionImpl{
+		NArgs:         1,
+		Deterministic: true,
+		MakeAggregate: func(modernc.FunctionContext) (modernc.AggregateFunction, error) {
+			return &sum{avg: avg}, nil
+		},
+	}
+}
+
+// sum is an invocation of dec_sum or dec_avg.
+// The values of the window are kept and summed again for every frame,
+// since a running total is rounded when it needs more than 19 digits,
+// and the digits rounded away cannot be restored when values are removed.
+type sum struct {
+	avg    bool
+	values []decimal.Decimal
+}
+
+func (s *sum) Step(_ *modernc.FunctionContext, args []driver.Value) error {
+	if args[0] == nil {
+		return nil
+	}
+	d, err := parse(args[0])
+	if err != nil {
+		return err
+	}
+	s.values = append(s.values, d)
+	return nil
+}
+
+// WindowInverse removes the oldest value of the window.
+func (s *sum) WindowInverse(_ *modernc.FunctionContext, args []driver.Value) error {
+	if args[0] == nil {
+		return nil
+	}
+	s.values = s.values[1:]
+	return nil
+}
+
+func (s *sum) WindowValue(*modernc.FunctionContext) (driver.Value, error) {
+	if len(s.values) == 0 {
+		return nil, nil
+	}
+	var r decimal.Decimal
+	var err error
+	if s.avg {
+		r, err = decimal.Mean(s.values...)
+	} else {
+		r, err = decimal.Sum(s.values...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.String(), nil
+}
+
+func (s *sum) Final(*modernc.FunctionContext) {}
@@ -1,142 +1,690 @@
 package sqlite_test
 
 import (
+	"database/sql"
+	"fmt"
 	"log"
-	"math"
 	"os"
+	"slices"
+	"strings"
 	"testing"
 
 	"github.com/govalues/decimal"
-	"github.com/jmoiron/sqlx"
-	_ "modernc.org/sqlite"
+	"github.com/govalues/decimal-tests/db/dbtest"
+	"github.com/govalues/decimal-tests/db/sqlite"
 )
 
-const url = ":memory:"
+type dialect struct{}
 
-var db *sqlx.DB
+func (dialect) Driver() string { return "sqlite" }
+
+func (dialect) DSN() string { return ":memory:" }
+
+func (dialect) Env() string { return "" }
+
+func (dialect) ColumnType(k dbtest.Kind) string {
+	switch k {
+	case dbtest.Int64:
+		return "INTEGER"
+	case dbtest.Float64:
+		return "REAL"
+	case dbtest.String:
+		return "TEXT"
+	}
+	return ""
+}
+
+func (dialect) Placeholder(n int) string { return fmt.Sprintf("$%v", n) }
+
+func (dialect) Returning() bool { return true }
+
+func (dialect) IDColumn() string { return "" }
+
+var db = dbtest.New(dialect{})
 
 func TestMain(m *testing.M) {
-	var err error
-	db, err = sqlx.Connect("sqlite", url)
+	// The functions must be registered before the connection is opened
+	err := sqlite.Register()
 	if err != nil {
-		log.Fatalf("Connect(%q) failed: %v\n", url, err)
+		log.Fatalf("Register() failed: %v", err)
 	}
-	defer db.Close()
-
-	os.Exit(m.Run())
+	os.Exit(db.Main(m))
 }
 
 func TestDecimal_insert(t *testing.T) {
-	const createTable = `
-CREATE TABLE IF NOT EXISTS decimal_insert (
-	int64   INTEGER,
-	float64 REAL,
-	string  TEXT
+	db.TestInsert(t)
+}
+
+func TestDecimal_selectNull(t *testing.T) {
+	db.TestDecimalNull(t)
+}
+
+func TestNullDecimal_selectNull(t *testing.T) {
+	db.TestNullDecimalNull(t)
+}
+
+// SQLite has no decimal type. Columns declared as NUMERIC or DECIMAL(19,2)
+// have numeric affinity: text that looks like a number is stored as an
+// INTEGER if it fits int64 and has no fractional part, and as a REAL
+// otherwise. The scale and every digit beyond float64 precision are lost,
+// and numbers with 20 digits are stored as 1e19, which does not fit
+// a decimal. TEXT columns keep the exact value.
+func TestDecimal_affinity(t *testing.T) {
+	const createTable = `CREATE TABLE IF NOT EXISTS decimal_affinity (
+	numeric_col NUMERIC,
+	decimal_col DECIMAL(19,2),
+	text_col TEXT
+)`
+	_, err := db.Exec(createTable)
+	if err != nil {
+		t.Fatalf("Exec(%q) failed: %v", createTable, err)
+	}
+	const (
+		insert = "INSERT INTO decimal_affinity VALUES ($1, $1, $1)"
+		query  = "SELECT typeof(numeric_col), typeof(decimal_col), numeric_col, decimal_col, text_col FROM decimal_affinity"
+	)
+
+	tests := []struct {
+		in, typ, want string
+		wantErr       bool
+	}{
+		{"0", "integer", "0", false},
+		{"0.1", "real", "0.1", false},
+		{"1.50", "real", "1.5", false},
+		{"1.00", "integer", "1", false},
+		{"-0.0000000000000000001", "real", "-0.0000000000000000001", false},
+		{"1234567890.123456789", "real", "1234567890.1234567", false},
+		{"12345678901234567.89", "integer", "12345678901234568", false},
+		{"9223372036854775807", "integer", "9223372036854775807", false},
+		{"9999999999999999999", "real", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			_, err := db.Exec("DELETE FROM decimal_affinity")
+			if err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+			in := decimal.MustParse(tt.in)
+			_, err = db.Exec(insert, in)
+			if err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+
+			var (
+				numericType, decimalType string
+				numeric, dec             decimal.NullDecimal
+				text                     decimal.Decimal
+			)
+			err = db.QueryRowx(query).Scan(&numericType, &decimalType, &numeric, &dec, &text)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Scan() did not fail, got %v and %v", numeric, dec)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+
+			if numericType != tt.typ || decimalType != tt.typ {
+				t.Errorf("typeof() = %v and %v, want %v", numericType, decimalType, tt.typ)
+			}
+			want := decimal.NullDecimal{Decimal: decimal.MustParse(tt.want), Valid: true}
+			if numeric != want {
+				t.Errorf("NUMERIC = %v, want %v", numeric, want)
+			}
+			if dec != want {
+				t.Errorf("DECIMAL(19,2) = %v, want %v", dec, want)
+			}
+			if text != in {
+				t.Errorf("TEXT = %v, want %v", text, in)
+			}
+		})
+	}
+}
+
+// SUM, AVG, and TOTAL add values as float64 unless every value is an
+// INTEGER, so their results drift from the exact sum of the decimals.
+// SUM of integers is exact, but fails instead of overflowing.
+func TestDecimal_aggregate(t *testing.T) {
+	const createTable = `CREATE TABLE IF NOT EXISTS decimal_aggregate (
+	integer_col INTEGER,
+	decimal_col DECIMAL(19,2),
+	text_col TEXT
 )`
 	_, err := db.Exec(createTable)
 	if err != nil {
 		t.Fatalf("Exec(%q) failed: %v", createTable, err)
 	}
 
+	tenths := []string{"0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.2"}
 	tests := []struct {
-		Int64       int64
-		WantInt64   string
-		Float64     float64
-		WantFloat64 string
-		String      string
-		WantString  string
+		name    string
+		values  []string
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{"tenths", tenths, "SUM(text_col)", "1.2000000000000002", false},
+		{"tenths", tenths, "SUM(decimal_col)", "1.2000000000000002", false},
+		{"tenths", tenths, "TOTAL(text_col)", "1.2000000000000002", false},
+		{"tenths", tenths, "AVG(text_col)", "0.10909090909090911", false},
+		{"cents", []string{"9007199254740993", "0.01"}, "SUM(text_col)", "9007199254740994", false},
+		{"integers", []string{"9007199254740993", "1"}, "SUM(integer_col)", "9007199254740994", false},
+		{"integers", []string{"9007199254740993", "1"}, "TOTAL(integer_col)", "9007199254740994", false},
+		{"integers", []string{"9007199254740993", "1"}, "AVG(integer_col)", "4503599627370497", false},
+		{"overflow", []string{"9223372036854775807", "1"}, "SUM(integer_col)", "", true},
+		{"overflow", []string{"9223372036854775807", "1"}, "TOTAL(integer_col)", "9223372036854776000", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/"+tt.query, func(t *testing.T) {
+			_, err := db.Exec("DELETE FROM decimal_aggregate")
+			if err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+			for _, v := range tt.values {
+				_, err = db.Exec("INSERT INTO decimal_aggregate VALUES ($1, $1, $1)", decimal.MustParse(v))
+				if err != nil {
+					t.Fatalf("Exec failed: %v", err)
+				}
+			}
+
+			var got decimal.Decimal
+			query := "SELECT " + tt.query + " FROM decimal_aggregate"
+			err = db.QueryRowx(query).Scan(&got)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("QueryRowx(%q) did not fail, got %v", query, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryRowx(%q) failed: %v", query, err)
+			}
+			if want := decimal.MustParse(tt.want); got != want {
+				t.Errorf("QueryRowx(%q) = %v, want %v", query, got, want)
+			}
+		})
+	}
+}
+
+// TEXT values are compared as strings, so "10.00" < "9.50" and
+// "1.0" != "1.00". A number compared with a TEXT column is converted
+// to text first. Casting to NUMERIC compares numbers, but as float64.
+func TestDecimal_compareText(t *testing.T) {
+	const createTable = "CREATE TABLE IF NOT EXISTS decimal_compare (amount TEXT)"
+	_, err := db.Exec(createTable)
+	if err != nil {
+		t.Fatalf("Exec(%q) failed: %v", createTable, err)
+	}
+	_, err = db.Exec("DELETE FROM decimal_compare")
+	if err != nil {
+		t.Fatalf("Exec failed: %v", err)
+	}
+	for _, v := range []string{"10.00", "9.50", "1", "1.0", "1.00", "0.30000000000000001"} {
+		_, err = db.Exec("INSERT INTO decimal_compare VALUES ($1)", decimal.MustParse(v))
+		if err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+
+	tests := []struct {
+		where string
+		want  []string
+	}{
+		{"amount > '9.50'", nil},
+		{"amount > 9.5", []string{"9.50"}},
+		{"amount = '1.0'", []string{"1.0"}},
+		{"amount = 1", []string{"1"}},
+		{"CAST(amount AS NUMERIC) = 1", []string{"1", "1.0", "1.00"}},
+		{"CAST(amount AS NUMERIC) > 9.5", []string{"10.00"}},
+		{"CAST(amount AS NUMERIC) = 0.3", []string{"0.30000000000000001"}},
+		{"amount = (SELECT MAX(amount) FROM decimal_compare)", []string{"9.50"}},
+	}
+	for _, tt := range tests {
+		query := "SELECT amount FROM decimal_compare WHERE " + tt.where + " ORDER BY rowid"
+		var got []string
+		err := db.Select(&got, query)
+		if err != nil {
+			t.Errorf("Select(%q) failed: %v", query, err)
+			continue
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Select(%q) = %v, want %v", query, got, tt.want)
+		}
+	}
+}
+
+func TestFunctions_scalar(t *testing.T) {
+	tests := []struct {
+		expr    string
+		want    sql.NullString
+		wantErr bool
+	}{
+		{"dec_add('0.1', '0.2')", valid("0.3"), false},
+		{"dec_add('1.50', '2.5')", valid("4.00"), false},
+		{"dec_add(2, '0.5')", valid("2.5"), false},
+		{"dec_add('9999999999999999999', '1')", sql.NullString{}, true},
+		{"dec_add(NULL, '1')", sql.NullString{}, false},
+		{"dec_sub('10.00', '9.50')", valid("0.50"), false},
+		{"dec_sub('0.3', '0.1')", valid("0.2"), false},
+		{"dec_mul('1.5', '1.5')", valid("2.25"), false},
+		{"dec_mul('0.0000000001', '0.0000000001')", valid("0.0000000000000000000"), false},
+		{"dec_div('1', '3')", valid("0.3333333333333333333"), false},
+		{"dec_div('10.00', '4')", valid("2.50"), false},
+		{"dec_div('1', '0')", sql.NullString{}, true},
+		{"dec_round('2.345', 2)", valid("2.34"), false},
+		{"dec_round('2.355', 2)", valid("2.36"), false},
+		{"dec_round('-2.5', 0)", valid("-2"), false},
+		{"dec_round('2.5', -1)", sql.NullString{}, true},
+		{"dec_round('2.5', '1')", sql.NullString{}, true},
+		{"dec_cmp('1.0', '1.00')", valid("0"), false},
+		{"dec_cmp('10.00', '9.50')", valid("1"), false},
+		{"dec_cmp('-1', '0')", valid("-1"), false},
+		{"dec_cmp('1', NULL)", sql.NullString{}, false},
+		{"dec_add('abc', '1')", sql.NullString{}, true},
+		{"dec_add(0.1, '1')", sql.NullString{}, true},
+		{"dec_add(x'31', '1')", valid("2"), false},
+	}
+	for _, tt := range tests {
+		query := "SELECT " + tt.expr
+		var got sql.NullString
+		err := db.QueryRowx(query).Scan(&got)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("QueryRowx(%q) did not fail, got %v", query, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("QueryRowx(%q) failed: %v", query, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("QueryRowx(%q) = %v, want %v", query, got, tt.want)
+		}
+	}
+}
+
+// The built-in SUM and AVG drift because they add float64 values,
+// while dec_sum and dec_avg return the same result as govalues.
+func TestFunctions_aggregate(t *testing.T) {
+	const createTable = "CREATE TABLE IF NOT EXISTS decimal_functions (grp INTEGER, amount TEXT)"
+	_, err := db.Exec(createTable)
+	if err != nil {
+		t.Fatalf("Exec(%q) failed: %v", createTable, err)
+	}
+
+	tests := []struct {
+		name    string
+		values  []string
+		sum     string
+		avg     string
+		native  string
+		wantErr bool
 	}{
-		// Largest negative values
 		{
-			math.MinInt64, "-9223372036854775808",
-			-9.99999999999999e+18, "-9999999999999990000",
-			"-9999999999999999999", "-9999999999999999999",
+			name:   "tenths",
+			values: []string{"0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.1", "0.2"},
+			sum:    "1.2",
+			avg:    "0.1090909090909090909",
+			native: "1.2000000000000002",
 		},
-		// Smallest negative values
 		{
-			-1, "-1",
-			-math.SmallestNonzeroFloat64, "0.0000000000000000000",
-			"-0.0000000000000000001", "-0.0000000000000000001",
+			name:   "cents",
+			values: []string{"9007199254740993", "0.01"},
+			sum:    "9007199254740993.01",
+			avg:    "4503599627370496.505",
+			native: "9007199254740994",
 		},
-		// Zero
 		{
-			0, "0",
-			0, "0",
-			"0.0000000000000000000", "0.0000000000000000000",
+			name:   "scales",
+			values: []string{"1.50", "2.5", "-4"},
+			sum:    "0.00",
+			avg:    "0.00",
+			native: "0",
 		},
-		// Pi
 		{
-			3, "3",
-			math.Pi, "3.141592653589793",
-			decimal.Pi.String(), "3.141592653589793238",
+			name:    "overflow",
+			values:  []string{"9999999999999999999", "1"},
+			wantErr: true,
 		},
-		// Smallest positive values
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := db.Exec("DELETE FROM decimal_functions")
+			if err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+			values := make([]decimal.Decimal, len(tt.values))
+			for i, v := range tt.values {
+				values[i] = decimal.MustParse(v)
+				_, err = db.Exec("INSERT INTO decimal_functions VALUES (1, $1)", values[i])
+				if err != nil {
+					t.Fatalf("Exec failed: %v", err)
+				}
+			}
+			_, err = db.Exec("INSERT INTO decimal_functions VALUES (1, NULL)")
+			if err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
+
+			const query = "SELECT dec_sum(amount), dec_avg(amount), SUM(amount) FROM decimal_functions"
+			var sum, avg, native decimal.Decimal
+			err = db.QueryRowx(query).Scan(&sum, &avg, &native)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("QueryRowx(%q) did not fail, got %v", query, sum)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("QueryRowx(%q) failed: %v", query, err)
+			}
+
+			want, err := decimal.Sum(values...)
+			if err != nil {
+				t.Fatalf("Sum(%v) failed: %v", values, err)
+			}
+			if sum != want || sum.String() != tt.sum {
+				t.Errorf("dec_sum() = %v, want %v", sum, tt.sum)
+			}
+			if want := decimal.MustParse(tt.avg); avg != want {
+				t.Errorf("dec_avg() = %v, want %v", avg, want)
+			}
+			if want := decimal.MustParse(tt.native); native != want {
+				t.Errorf("SUM() = %v, want %v", native, want)
+			}
+		})
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		const query = "SELECT dec_sum(amount), dec_avg(amount) FROM decimal_functions WHERE grp = 2"
+		var sum, avg decimal.NullDecimal
+		err := db.QueryRowx(query).Scan(&sum, &avg)
+		if err != nil {
+			t.Fatalf("QueryRowx(%q) failed: %v", query, err)
+		}
+		if sum.Valid || avg.Valid {
+			t.Errorf("QueryRowx(%q) = %v, %v, want NULL", query, sum, avg)
+		}
+	})
+}
+
+func TestFunctions_window(t *testing.T) {
+	const createTable = "CREATE TABLE IF NOT EXISTS decimal_window (amount TEXT)"
+	_, err := db.Exec(createTable)
+	if err != nil {
+		t.Fatalf("Exec(%q) failed: %v", createTable, err)
+	}
+
+	// A moving window removes rows with WindowInverse
+	const query = `SELECT
+	dec_sum(amount) OVER (ORDER BY rowid ROWS BETWEEN 1 PRECEDING AND CURRENT ROW),
+	dec_avg(amount) OVER (ORDER BY rowid ROWS BETWEEN 1 PRECEDING AND CURRENT ROW)
+FROM decimal_window ORDER BY rowid`
+
+	tests := []struct {
+		values []string
+		want   []string
+	}{
 		{
-			1, "1",
-			math.SmallestNonzeroFloat64, "0.0000000000000000000",
-			"0.0000000000000000001", "0.0000000000000000001",
+			values: []string{"0.1", "0.2", "0.3", "0.4"},
+			want:   []string{"0.1", "0.1", "0.3", "0.15", "0.5", "0.25", "0.7", "0.35"},
 		},
-		// Largest positive values
+		// The scale of the result is the largest scale in the window,
+		// like in decimal.Sum
 		{
-			math.MaxInt64, "9223372036854775807",
-			9.99999999999999e+18, "9999999999999990000",
-			"9999999999999999999", "9999999999999999999",
+			values: []string{"0.10", "1", "2"},
+			want:   []string{"0.10", "0.10", "1.10", "0.55", "3", "1.5"},
+		},
+		// Digits rounded away in a larger frame are not lost
+		{
+			values: []string{"1000000000000000000", "0.0000000000000000001", "0"},
+			want: []string{
+				"1000000000000000000", "1000000000000000000",
+				"1000000000000000000", "500000000000000000.0",
+				"0.0000000000000000001", "0.0000000000000000000",
+			},
 		},
 	}
-
 	for _, tt := range tests {
-		var got struct {
-			Int64   decimal.Decimal `db:"int64"`
-			Float64 decimal.Decimal `db:"float64"`
-			String  decimal.Decimal `db:"string"`
-		}
-
-		const insert = `
-INSERT INTO decimal_insert (int64, float64, string)
-VALUES ($1, $2, $3)
-RETURNING int64, float64, string`
-
-		err := db.QueryRowx(
-			insert,
-			tt.Int64,
-			tt.Float64,
-			tt.String,
-		).StructScan(&got)
+		_, err = db.Exec("DELETE FROM decimal_window")
 		if err != nil {
-			t.Fatalf("StructScan failed: %v", err)
+			t.Fatalf("Exec failed: %v", err)
+		}
+		for _, v := range tt.values {
+			_, err = db.Exec("INSERT INTO decimal_window VALUES ($1)", decimal.MustParse(v))
+			if err != nil {
+				t.Fatalf("Exec failed: %v", err)
+			}
 		}
 
-		if got.Int64 != decimal.MustParse(tt.WantInt64) {
-			t.Errorf("Int64 = %v, want %v", got.Int64, tt.WantInt64)
+		rows, err := db.Queryx(query)
+		if err != nil {
+			t.Fatalf("Queryx(%q) failed: %v", query, err)
+		}
+		var got []string
+		for rows.Next() {
+			var sum, avg string
+			err = rows.Scan(&sum, &avg)
+			if err != nil {
+				t.Fatalf("Scan failed: %v", err)
+			}
+			got = append(got, sum, avg)
 		}
-		if got.Float64 != decimal.MustParse(tt.WantFloat64) {
-			t.Errorf("Float64 = %v, want %v", got.Float64, tt.WantFloat64)
+		if err = rows.Err(); err != nil {
+			t.Fatalf("Next failed: %v", err)
 		}
-		if got.String != decimal.MustParse(tt.WantString) {
-			t.Errorf("String = %v, want %v", got.String, tt.WantString)
+		rows.Close()
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("Queryx(%q) over %v = %v, want %v", query, tt.values, got, tt.want)
 		}
 	}
 }
 
-func TestDecimal_selectNull(t *testing.T) {
-	const selectNull = "SELECT null"
-	var got decimal.Decimal
-	err := db.QueryRowx(selectNull).Scan(&got)
+func valid(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: true}
+}
+
+// collationValues are unordered, and some of them are equal
+// with different scales.
+var collationValues = []string{
+	"10.00", "9.50", "1", "-0.5", "1.00", "0.30000000000000001",
+	"-10", "0.3", "1.0", "9999999999999999999", "-0.0000000000000000001", "0",
+}
+
+// createCollationTable creates an indexed TEXT column with the decimal
+// collation and inserts collationValues.
+func createCollationTable(t *testing.T, name string) {
+	t.Helper()
+	for _, query := range []string{
+		"DROP TABLE IF EXISTS " + name,
+		"CREATE TABLE " + name + " (amount TEXT COLLATE decimal)",
+		"CREATE INDEX " + name + "_amount ON " + name + " (amount)",
+	} {
+		_, err := db.Exec(query)
+		if err != nil {
+			t.Fatalf("Exec(%q) failed: %v", query, err)
+		}
+	}
+	for _, v := range collationValues {
+		_, err := db.Exec("INSERT INTO "+name+" VALUES ($1)", decimal.MustParse(v))
+		if err != nil {
+			t.Fatalf("Exec failed: %v", err)
+		}
+	}
+}
+
+// sortedValues returns collationValues sorted in Go.
+func sortedValues() []decimal.Decimal {
+	d := make([]decimal.Decimal, len(collationValues))
+	for i, v := range collationValues {
+		d[i] = decimal.MustParse(v)
+	}
+	slices.SortStableFunc(d, decimal.Decimal.Cmp)
+	return d
+}
+
+func TestCollation_orderBy(t *testing.T) {
+	createCollationTable(t, "decimal_collation")
+	want := sortedValues()
+	tests := []struct {
+		query   string
+		reverse bool
+	}{
+		{"SELECT amount FROM decimal_collation ORDER BY amount", false},
+		{"SELECT amount FROM decimal_collation ORDER BY amount DESC", true},
+		{"SELECT amount FROM decimal_collation WHERE amount > '-1000' ORDER BY amount", false},
+	}
+	for _, tt := range tests {
+		var got []decimal.Decimal
+		err := db.Select(&got, tt.query)
+		if err != nil {
+			t.Errorf("Select(%q) failed: %v", tt.query, err)
+			continue
+		}
+		if tt.reverse {
+			slices.Reverse(got)
+		}
+		// Equal values with different scales may come in any order
+		if !slices.EqualFunc(got, want, func(x, y decimal.Decimal) bool { return x.Cmp(y) == 0 }) {
+			t.Errorf("Select(%q) = %v, want %v", tt.query, got, want)
+		}
+	}
+
+	// Without the collation, TEXT is ordered as strings
+	const query = "SELECT amount FROM decimal_collation ORDER BY amount COLLATE BINARY"
+	var got []string
+	err := db.Select(&got, query)
+	if err != nil {
+		t.Fatalf("Select(%q) failed: %v", query, err)
+	}
+	strs := slices.Clone(collationValues)
+	slices.Sort(strs)
+	if !slices.Equal(got, strs) {
+		t.Errorf("Select(%q) = %v, want %v", query, got, strs)
+	}
+}
+
+func TestCollation_minMax(t *testing.T) {
+	createCollationTable(t, "decimal_collation")
+	want := sortedValues()
+	const query = "SELECT MIN(amount), MAX(amount) FROM decimal_collation"
+	var lo, hi decimal.Decimal
+	err := db.QueryRowx(query).Scan(&lo, &hi)
+	if err != nil {
+		t.Fatalf("QueryRowx(%q) failed: %v", query, err)
+	}
+	if lo != want[0] || hi != want[len(want)-1] {
+		t.Errorf("QueryRowx(%q) = %v, %v, want %v, %v", query, lo, hi, want[0], want[len(want)-1])
+	}
+}
+
+func TestCollation_equal(t *testing.T) {
+	createCollationTable(t, "decimal_collation")
+	tests := []struct {
+		where string
+		want  []string
+	}{
+		{"amount = '1'", []string{"1", "1.00", "1.0"}},
+		{"amount = '1.000000'", []string{"1", "1.00", "1.0"}},
+		{"amount = '0.3'", []string{"0.3"}},
+		{"amount = '-0'", []string{"0"}},
+		{"amount IN ('10', '9.5')", []string{"10.00", "9.50"}},
+		{"amount > '9.5'", []string{"10.00", "9999999999999999999"}},
+		{"amount BETWEEN '-1' AND '0'", []string{"-0.5", "-0.0000000000000000001", "0"}},
+	}
+	for _, tt := range tests {
+		query := "SELECT amount FROM decimal_collation WHERE " + tt.where + " ORDER BY rowid"
+		var got []string
+		err := db.Select(&got, query)
+		if err != nil {
+			t.Errorf("Select(%q) failed: %v", query, err)
+			continue
+		}
+		// Compare with the values that are equal in Go
+		var want []string
+		for _, v := range collationValues {
+			if slices.Contains(tt.want, v) {
+				want = append(want, v)
+			}
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Select(%q) = %v, want %v", query, got, want)
+		}
+	}
+
+	// A unique column rejects a value with a different scale
+	for _, query := range []string{
+		"DROP TABLE IF EXISTS decimal_unique",
+		"CREATE TABLE decimal_unique (amount TEXT COLLATE decimal UNIQUE)",
+		"INSERT INTO decimal_unique VALUES ('1.0')",
+	} {
+		_, err := db.Exec(query)
+		if err != nil {
+			t.Fatalf("Exec(%q) failed: %v", query, err)
+		}
+	}
+	const insert = "INSERT INTO decimal_unique VALUES ('1.00')"
+	_, err := db.Exec(insert)
 	if err == nil {
-		t.Errorf("QueryRowx(%q) did not fail, got %v", selectNull, got)
+		t.Errorf("Exec(%q) did not fail", insert)
 	}
 }
 
-func TestNullDecimal_selectNull(t *testing.T) {
-	const selectNull = "SELECT null"
-	var got decimal.NullDecimal
-	err := db.QueryRowx(selectNull).Scan(&got)
+func TestCollation_index(t *testing.T) {
+	createCollationTable(t, "decimal_collation")
+	tests := []struct {
+		query string
+		plan  string
+	}{
+		{"SELECT amount FROM decimal_collation WHERE amount = '1.0'", "USING COVERING INDEX decimal_collation_amount (amount=?)"},
+		{"SELECT amount FROM decimal_collation WHERE amount > '9.5'", "USING COVERING INDEX decimal_collation_amount (amount>?)"},
+		{"SELECT amount FROM decimal_collation ORDER BY amount", "USING COVERING INDEX decimal_collation_amount"},
+		{"SELECT MAX(amount) FROM decimal_collation", "USING COVERING INDEX decimal_collation_amount"},
+		// A different collation cannot use the index
+		{"SELECT amount FROM decimal_collation WHERE amount = '1.0' COLLATE BINARY", "SCAN decimal_collation"},
+	}
+	for _, tt := range tests {
+		query := "EXPLAIN QUERY PLAN " + tt.query
+		rows, err := db.Queryx(query)
+		if err != nil {
+			t.Errorf("Queryx(%q) failed: %v", query, err)
+			continue
+		}
+		var plan []string
+		for rows.Next() {
+			var id, parent, notused int
+			var detail string
+			err = rows.Scan(&id, &parent, &notused, &detail)
+			if err != nil {
+				break
+			}
+			plan = append(plan, detail)
+		}
+		rows.Close()
+		if err != nil {
+			t.Errorf("Scan failed: %v", err)
+			continue
+		}
+		if !slices.ContainsFunc(plan, func(s string) bool { return strings.HasSuffix(s, tt.plan) }) {
+			t.Errorf("Queryx(%q) = %q, want %q", query, plan, tt.plan)
+		}
+	}
+}
+
+func TestCollation_invalid(t *testing.T) {
+	const query = `SELECT amount FROM (
+	SELECT 'abc' AS amount UNION ALL SELECT '10' UNION ALL SELECT '' UNION ALL SELECT '9.5'
+) ORDER BY amount COLLATE decimal`
+	var got []string
+	err := db.Select(&got, query)
 	if err != nil {
-		t.Errorf("QueryRowx(%q) failed: %v", selectNull, err)
-		return
+		t.Fatalf("Select(%q) failed: %v", query, err)
 	}
-	want := decimal.NullDecimal{}
-	if got != want {
-		t.Errorf("Scan() = %v, want %v", got, want)
+	want := []string{"9.5", "10", "", "abc"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Select(%q) = %q, want %q", query, got, want)
 	}
 }
@@ -6,70 +6,22 @@ import (
 	"testing"
 
 	cd "github.com/cockroachdb/apd/v3"
+	el "github.com/ericlagergren/decimal"
 	gv "github.com/govalues/decimal"
+	"github.com/govalues/decimal-tests/internal/corpus"
 	ss "github.com/shopspring/decimal"
 )
 
-var corpus = []struct {
-	scale int
-	coef  int64
-}{
-	{0, math.MaxInt64},
-	{1, math.MaxInt64},
-	{2, math.MaxInt64},
-	{0, 5000000000000000000},
-	{0, 1000000000000000000},
-	{19, math.MaxInt64},
-	{0, 9},
-	{0, 7},
-	{0, 6},
-	{0, 3},
-	{0, 2},
-	{0, 1},
-	{18, 3000000000000000003},
-	{18, 3000000000000000000},
-	{18, 2000000000000000002},
-	{18, 2000000000000000000},
-	{18, 1000000000000000001},
-	{18, 1000000000000000000},
-	{19, 3},
-	{19, 2},
-	{19, 1},
-	{0, 0},
-	{19, 0},
-	{0, math.MinInt64},
-	{1, math.MinInt64},
-	{2, math.MinInt64},
-	{0, -5000000000000000000},
-	{0, -1000000000000000000},
-	{19, math.MinInt64},
-	{0, -9},
-	{0, -7},
-	{0, -6},
-	{0, -3},
-	{0, -2},
-	{0, -1},
-	{18, -3000000000000000003},
-	{18, -3000000000000000000},
-	{18, -2000000000000000002},
-	{18, -2000000000000000000},
-	{18, -1000000000000000001},
-	{18, -1000000000000000000},
-	{19, -3},
-	{19, -2},
-	{19, -1},
-}
-
 func FuzzSum(f *testing.F) {
 	ss.DivisionPrecision = 100
 	ss.PowPrecisionNegativeExponent = 100
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			for _, g := range corpus {
-				f.Add(d.coef, d.scale, e.coef, e.scale, g.coef, g.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, g := range corpus.Decimals {
+				f.Add(d.Coef, d.Scale, e.Coef, e.Scale, g.Coef, g.Scale)
 			}
 		}
 	}
@@ -91,6 +43,18 @@ func FuzzSum(f *testing.F) {
 			t.Errorf("sumGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := sumEL(dcoef, dscale, ecoef, escale, fcoef, fscale)
+			if err != nil {
+				t.Errorf("sumEL(%v, %v, %v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, fcoef, fscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("sumGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := sumSS(dcoef, dscale, ecoef, escale, fcoef, fscale)
 		if err != nil {
@@ -109,10 +73,10 @@ func FuzzProd(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			for _, g := range corpus {
-				f.Add(d.coef, d.scale, e.coef, e.scale, g.coef, g.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, g := range corpus.Decimals {
+				f.Add(d.Coef, d.Scale, e.Coef, e.Scale, g.Coef, g.Scale)
 			}
 		}
 	}
@@ -134,6 +98,18 @@ func FuzzProd(f *testing.F) {
 			t.Errorf("prodGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := prodEL(dcoef, dscale, ecoef, escale, fcoef, fscale)
+			if err != nil {
+				t.Errorf("prodEL(%v, %v, %v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, fcoef, fscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("prodGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := prodSS(dcoef, dscale, ecoef, escale, fcoef, fscale)
 		if err != nil {
@@ -152,10 +128,10 @@ func FuzzMean(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			for _, g := range corpus {
-				f.Add(d.coef, d.scale, e.coef, e.scale, g.coef, g.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, g := range corpus.Decimals {
+				f.Add(d.Coef, d.Scale, e.Coef, e.Scale, g.Coef, g.Scale)
 			}
 		}
 	}
@@ -177,6 +153,18 @@ func FuzzMean(f *testing.F) {
 			t.Errorf("meanGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := meanEL(dcoef, dscale, ecoef, escale, fcoef, fscale)
+			if err != nil {
+				t.Errorf("meanEL(%v, %v, %v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, fcoef, fscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("meanGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := meanSS(dcoef, dscale, ecoef, escale, fcoef, fscale)
 		if err != nil {
@@ -195,9 +183,9 @@ func FuzzDecimal_Add(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			f.Add(d.coef, d.scale, e.coef, e.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			f.Add(d.Coef, d.Scale, e.Coef, e.Scale)
 		}
 	}
 
@@ -218,6 +206,18 @@ func FuzzDecimal_Add(f *testing.F) {
 			t.Errorf("addGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := addEL(dcoef, dscale, ecoef, escale)
+			if err != nil {
+				t.Errorf("addEL(%v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("addGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := addSS(dcoef, dscale, ecoef, escale)
 		if err != nil {
@@ -236,9 +236,9 @@ func FuzzDecimal_Mul(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			f.Add(d.coef, d.scale, e.coef, e.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			f.Add(d.Coef, d.Scale, e.Coef, e.Scale)
 		}
 	}
 
@@ -259,6 +259,18 @@ func FuzzDecimal_Mul(f *testing.F) {
 			t.Errorf("mulGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := mulEL(dcoef, dscale, ecoef, escale)
+			if err != nil {
+				t.Errorf("mulEL(%v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("mulGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := mulSS(dcoef, dscale, ecoef, escale)
 		if err != nil {
@@ -277,10 +289,10 @@ func FuzzDecimal_AddMul(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			for _, g := range corpus {
-				f.Add(d.coef, d.scale, e.coef, e.scale, g.coef, g.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, g := range corpus.Decimals {
+				f.Add(d.Coef, d.Scale, e.Coef, e.Scale, g.Coef, g.Scale)
 			}
 		}
 	}
@@ -302,6 +314,18 @@ func FuzzDecimal_AddMul(f *testing.F) {
 			t.Errorf("addMulGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := addMulEL(dcoef, dscale, ecoef, escale, fcoef, fscale)
+			if err != nil {
+				t.Errorf("addMulEL(%v, %v, %v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, fcoef, fscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("addMulGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := addMulSS(dcoef, dscale, ecoef, escale, fcoef, fscale)
 		if err != nil {
@@ -321,10 +345,10 @@ func FuzzDecimal_AddQuo(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			for _, g := range corpus {
-				f.Add(d.coef, d.scale, e.coef, e.scale, g.coef, g.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, g := range corpus.Decimals {
+				f.Add(d.Coef, d.Scale, e.Coef, e.Scale, g.Coef, g.Scale)
 			}
 		}
 	}
@@ -346,6 +370,18 @@ func FuzzDecimal_AddQuo(f *testing.F) {
 			t.Errorf("addQuoGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := addQuoEL(dcoef, dscale, ecoef, escale, fcoef, fscale)
+			if err != nil {
+				t.Errorf("addQuoEL(%v, %v, %v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, fcoef, fscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("addQuoGV(%v, %v, %v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, fcoef, fscale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := addQuoSS(dcoef, dscale, ecoef, escale, fcoef, fscale)
 		if err != nil {
@@ -365,9 +401,9 @@ func FuzzDecimal_Quo(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			f.Add(d.coef, d.scale, e.coef, e.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			f.Add(d.Coef, d.Scale, e.Coef, e.Scale)
 		}
 	}
 
@@ -388,6 +424,18 @@ func FuzzDecimal_Quo(f *testing.F) {
 			t.Errorf("quoGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := quoEL(dcoef, dscale, ecoef, escale)
+			if err != nil {
+				t.Errorf("quoEL(%v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("quoGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := divSS(dcoef, dscale, ecoef, escale)
 		if err != nil {
@@ -407,9 +455,9 @@ func FuzzDecimal_QuoRem(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			f.Add(d.coef, d.scale, e.coef, e.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			f.Add(d.Coef, d.Scale, e.Coef, e.Scale)
 		}
 	}
 
@@ -427,9 +475,21 @@ func FuzzDecimal_QuoRem(f *testing.F) {
 			return
 		}
 		if gotQGV != wantQCD || gotRGV != wantRCD {
-			t.Errorf("quoRemGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotQGV, wantQCD)
+			t.Errorf("quoRemGV(%v, %v, %v, %v) = %v, %v, want %v, %v", dcoef, dscale, ecoef, escale, gotQGV, gotRGV, wantQCD, wantRCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantQEL, wantREL, err := quoRemEL(dcoef, dscale, ecoef, escale)
+			if err != nil {
+				t.Errorf("quoRemEL(%v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, err)
+				return
+			}
+			if gotQGV != wantQEL || gotRGV != wantREL {
+				t.Errorf("quoRemGV(%v, %v, %v, %v) = %v, %v, want %v, %v", dcoef, dscale, ecoef, escale, gotQGV, gotRGV, wantQEL, wantREL)
+				return
+			}
+		}
 		// ShopSpring
 		wantQSS, wantRSS, err := quoRemSS(dcoef, dscale, ecoef, escale)
 		if err != nil {
@@ -437,7 +497,7 @@ func FuzzDecimal_QuoRem(f *testing.F) {
 			return
 		}
 		if gotQGV != wantQSS || gotRGV != wantRSS {
-			t.Errorf("quoRemGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotQGV, wantQSS)
+			t.Errorf("quoRemGV(%v, %v, %v, %v) = %v, %v, want %v, %v", dcoef, dscale, ecoef, escale, gotQGV, gotRGV, wantQSS, wantRSS)
 			return
 		}
 	})
@@ -449,9 +509,9 @@ func FuzzDecimal_PowInt(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
+	for _, d := range corpus.Decimals {
 		for p := -10; p <= 10; p++ {
-			f.Add(d.coef, d.scale, p)
+			f.Add(d.Coef, d.Scale, p)
 		}
 	}
 
@@ -472,6 +532,18 @@ func FuzzDecimal_PowInt(f *testing.F) {
 			t.Errorf("powIntGV(%v, %v, %v) = %v, want %v", dcoef, dscale, power, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := powIntEL(dcoef, dscale, power)
+			if err != nil {
+				t.Errorf("powIntEL(%v, %v, %v) failed: %v", dcoef, dscale, power, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("powIntGV(%v, %v, %v) = %v, want %v", dcoef, dscale, power, gotGV, wantEL)
+				return
+			}
+		}
 		// ShopSpring
 		if dcoef == 0 {
 			t.Skip()
@@ -494,8 +566,8 @@ func FuzzDecimal_Sqrt(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		f.Add(d.coef, d.scale)
+	for _, d := range corpus.Decimals {
+		f.Add(d.Coef, d.Scale)
 	}
 
 	f.Fuzz(func(t *testing.T, dcoef int64, dscale int) {
@@ -515,6 +587,22 @@ func FuzzDecimal_Sqrt(f *testing.F) {
 			t.Errorf("sqrtGV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, ulpEL, err := sqrtEL(dcoef, dscale)
+			if err != nil {
+				t.Errorf("sqrtEL(%v, %v) failed: %v", dcoef, dscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("sqrtGV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantEL)
+				return
+			}
+			if !withinULP(gotGV, ulpEL) {
+				t.Errorf("sqrtGV(%v, %v) = %v, want %v ± 1 ulp in a 19-digit context", dcoef, dscale, gotGV, ulpEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := sqrtSS(dcoef, dscale)
 		if err != nil {
@@ -533,8 +621,8 @@ func FuzzDecimal_Exp(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		f.Add(d.coef, d.scale)
+	for _, d := range corpus.Decimals {
+		f.Add(d.Coef, d.Scale)
 	}
 
 	f.Fuzz(func(t *testing.T, dcoef int64, dscale int) {
@@ -559,6 +647,22 @@ func FuzzDecimal_Exp(f *testing.F) {
 			t.Errorf("expGV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, ulpEL, err := expEL(dcoef, dscale)
+			if err != nil {
+				t.Errorf("expEL(%v, %v) failed: %v", dcoef, dscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("expGV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantEL)
+				return
+			}
+			if !withinULP(gotGV, ulpEL) {
+				t.Errorf("expGV(%v, %v) = %v, want %v ± 1 ulp in a 19-digit context", dcoef, dscale, gotGV, ulpEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := expSS(dcoef, dscale)
 		if err != nil {
@@ -577,8 +681,8 @@ func FuzzDecimal_Log(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		f.Add(d.coef, d.scale)
+	for _, d := range corpus.Decimals {
+		f.Add(d.Coef, d.Scale)
 	}
 
 	f.Fuzz(func(t *testing.T, dcoef int64, dscale int) {
@@ -598,6 +702,22 @@ func FuzzDecimal_Log(f *testing.F) {
 			t.Errorf("logGV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, ulpEL, err := logEL(dcoef, dscale)
+			if err != nil {
+				t.Errorf("logEL(%v, %v) failed: %v", dcoef, dscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("logGV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantEL)
+				return
+			}
+			if !withinULP(gotGV, ulpEL) {
+				t.Errorf("logGV(%v, %v) = %v, want %v ± 1 ulp in a 19-digit context", dcoef, dscale, gotGV, ulpEL)
+				return
+			}
+		}
 		// ShopSpring
 		wantSS, err := logSS(dcoef, dscale)
 		if err != nil {
@@ -614,8 +734,8 @@ func FuzzDecimal_Log2(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		f.Add(d.coef, d.scale)
+	for _, d := range corpus.Decimals {
+		f.Add(d.Coef, d.Scale)
 	}
 
 	f.Fuzz(func(t *testing.T, dcoef int64, dscale int) {
@@ -635,8 +755,31 @@ func FuzzDecimal_Log2(f *testing.F) {
 			t.Errorf("log2GV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, ulpEL, err := log2EL(dcoef, dscale)
+			if err != nil {
+				t.Errorf("log2EL(%v, %v) failed: %v", dcoef, dscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("log2GV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantEL)
+				return
+			}
+			if !withinULP(gotGV, ulpEL) {
+				t.Errorf("log2GV(%v, %v) = %v, want %v ± 1 ulp in a 19-digit context", dcoef, dscale, gotGV, ulpEL)
+				return
+			}
+		}
 		// ShopSpring
-		// There is no log2 function.
+		wantSS, err := log2SS(dcoef, dscale)
+		if err != nil {
+			t.Errorf("log2SS(%v, %v) failed: %v", dcoef, dscale, err)
+			return
+		}
+		if gotGV != wantSS {
+			t.Errorf("log2GV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantSS)
+		}
 	})
 }
 
@@ -644,8 +787,8 @@ func FuzzDecimal_Log10(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		f.Add(d.coef, d.scale)
+	for _, d := range corpus.Decimals {
+		f.Add(d.Coef, d.Scale)
 	}
 
 	f.Fuzz(func(t *testing.T, dcoef int64, dscale int) {
@@ -665,6 +808,22 @@ func FuzzDecimal_Log10(f *testing.F) {
 			t.Errorf("log10GV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, ulpEL, err := log10EL(dcoef, dscale)
+			if err != nil {
+				t.Errorf("log10EL(%v, %v) failed: %v", dcoef, dscale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("log10GV(%v, %v) = %v, want %v", dcoef, dscale, gotGV, wantEL)
+				return
+			}
+			if !withinULP(gotGV, ulpEL) {
+				t.Errorf("log10GV(%v, %v) = %v, want %v ± 1 ulp in a 19-digit context", dcoef, dscale, gotGV, ulpEL)
+				return
+			}
+		}
 		// ShopSpring
 		// There is no log10 function.
 	})
@@ -674,9 +833,9 @@ func FuzzDecimal_Pow(f *testing.F) {
 	cd.BaseContext.Precision = 100
 	cd.BaseContext.Rounding = cd.RoundHalfEven
 
-	for _, d := range corpus {
-		for _, e := range corpus {
-			f.Add(d.coef, d.scale, e.coef, e.scale)
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			f.Add(d.Coef, d.Scale, e.Coef, e.Scale)
 		}
 	}
 
@@ -705,12 +864,246 @@ func FuzzDecimal_Pow(f *testing.F) {
 			t.Errorf("powGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantCD)
 			return
 		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, ulpEL, err := powEL(dcoef, dscale, ecoef, escale)
+			if err != nil {
+				t.Errorf("powEL(%v, %v, %v, %v) failed: %v", dcoef, dscale, ecoef, escale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("powGV(%v, %v, %v, %v) = %v, want %v", dcoef, dscale, ecoef, escale, gotGV, wantEL)
+				return
+			}
+			if !withinULP(gotGV, ulpEL) {
+				t.Errorf("powGV(%v, %v, %v, %v) = %v, want %v ± 1 ulp in a 19-digit context", dcoef, dscale, ecoef, escale, gotGV, ulpEL)
+				return
+			}
+		}
 		// ShopSpring
 		// Unfortunately, ShopSpring just hungs in many cases.
 		// For example, 1.000000000000000001^92233720368547758.07
 	})
 }
 
+func FuzzDecimal_Round(f *testing.F) {
+	ss.DivisionPrecision = 100
+	ss.PowPrecisionNegativeExponent = 100
+	cd.BaseContext.Precision = 100
+	cd.BaseContext.Rounding = cd.RoundHalfEven
+
+	for _, d := range corpus.Decimals {
+		for s := 0; s <= gv.MaxScale; s++ {
+			f.Add(d.Coef, d.Scale, s)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, scale int) {
+		// GoValues
+		gotGV, ok := roundHalfEvenGV(dcoef, dscale, scale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		// Cockroach DB
+		wantCD, err := roundHalfEvenCD(dcoef, dscale, scale)
+		if err != nil {
+			t.Errorf("roundHalfEvenCD(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+			return
+		}
+		if gotGV != wantCD {
+			t.Errorf("roundHalfEvenGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantCD)
+			return
+		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := roundHalfEvenEL(dcoef, dscale, scale)
+			if err != nil {
+				t.Errorf("roundHalfEvenEL(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("roundHalfEvenGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantEL)
+				return
+			}
+		}
+		// ShopSpring
+		wantSS, err := roundHalfEvenSS(dcoef, dscale, scale)
+		if err != nil {
+			t.Errorf("roundHalfEvenSS(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+			return
+		}
+		if gotGV != wantSS {
+			t.Errorf("roundHalfEvenGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantSS)
+		}
+	})
+}
+
+func FuzzDecimal_Trunc(f *testing.F) {
+	ss.DivisionPrecision = 100
+	ss.PowPrecisionNegativeExponent = 100
+	cd.BaseContext.Precision = 100
+	cd.BaseContext.Rounding = cd.RoundHalfEven
+
+	for _, d := range corpus.Decimals {
+		for s := 0; s <= gv.MaxScale; s++ {
+			f.Add(d.Coef, d.Scale, s)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, scale int) {
+		// GoValues
+		gotGV, ok := truncGV(dcoef, dscale, scale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		// Cockroach DB
+		wantCD, err := truncCD(dcoef, dscale, scale)
+		if err != nil {
+			t.Errorf("truncCD(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+			return
+		}
+		if gotGV != wantCD {
+			t.Errorf("truncGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantCD)
+			return
+		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := truncEL(dcoef, dscale, scale)
+			if err != nil {
+				t.Errorf("truncEL(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("truncGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantEL)
+				return
+			}
+		}
+		// ShopSpring
+		wantSS, err := truncSS(dcoef, dscale, scale)
+		if err != nil {
+			t.Errorf("truncSS(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+			return
+		}
+		if gotGV != wantSS {
+			t.Errorf("truncGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantSS)
+		}
+	})
+}
+
+func FuzzDecimal_Ceil(f *testing.F) {
+	ss.DivisionPrecision = 100
+	ss.PowPrecisionNegativeExponent = 100
+	cd.BaseContext.Precision = 100
+	cd.BaseContext.Rounding = cd.RoundHalfEven
+
+	for _, d := range corpus.Decimals {
+		for s := 0; s <= gv.MaxScale; s++ {
+			f.Add(d.Coef, d.Scale, s)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, scale int) {
+		// GoValues
+		gotGV, ok := ceilGV(dcoef, dscale, scale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		// Cockroach DB
+		// Quantize returns zero if all digits are discarded.
+		if d := cd.New(dcoef, int32(-dscale)); d.NumDigits() >= int64(dscale-scale) {
+			wantCD, err := ceilCD(dcoef, dscale, scale)
+			if err != nil {
+				t.Errorf("ceilCD(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+				return
+			}
+			if gotGV != wantCD {
+				t.Errorf("ceilGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantCD)
+				return
+			}
+		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := ceilEL(dcoef, dscale, scale)
+			if err != nil {
+				t.Errorf("ceilEL(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("ceilGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantEL)
+				return
+			}
+		}
+		// ShopSpring
+		wantSS, err := ceilSS(dcoef, dscale, scale)
+		if err != nil {
+			t.Errorf("ceilSS(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+			return
+		}
+		if gotGV != wantSS {
+			t.Errorf("ceilGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantSS)
+		}
+	})
+}
+
+func FuzzDecimal_Floor(f *testing.F) {
+	ss.DivisionPrecision = 100
+	ss.PowPrecisionNegativeExponent = 100
+	cd.BaseContext.Precision = 100
+	cd.BaseContext.Rounding = cd.RoundHalfEven
+
+	for _, d := range corpus.Decimals {
+		for s := 0; s <= gv.MaxScale; s++ {
+			f.Add(d.Coef, d.Scale, s)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, dcoef int64, dscale int, scale int) {
+		// GoValues
+		gotGV, ok := floorGV(dcoef, dscale, scale)
+		if !ok {
+			t.Skip()
+			return
+		}
+		// Cockroach DB
+		// Quantize returns zero if all digits are discarded.
+		if d := cd.New(dcoef, int32(-dscale)); d.NumDigits() >= int64(dscale-scale) {
+			wantCD, err := floorCD(dcoef, dscale, scale)
+			if err != nil {
+				t.Errorf("floorCD(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+				return
+			}
+			if gotGV != wantCD {
+				t.Errorf("floorGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantCD)
+				return
+			}
+		}
+		// Eric Lagergren
+		if !is32bit {
+			wantEL, err := floorEL(dcoef, dscale, scale)
+			if err != nil {
+				t.Errorf("floorEL(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+				return
+			}
+			if gotGV != wantEL {
+				t.Errorf("floorGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantEL)
+				return
+			}
+		}
+		// ShopSpring
+		wantSS, err := floorSS(dcoef, dscale, scale)
+		if err != nil {
+			t.Errorf("floorSS(%v, %v, %v) failed: %v", dcoef, dscale, scale, err)
+			return
+		}
+		if gotGV != wantSS {
+			t.Errorf("floorGV(%v, %v, %v) = %v, want %v", dcoef, dscale, scale, gotGV, wantSS)
+		}
+	})
+}
+
 func powGV(dcoef int64, dscale int, ecoef int64, escale int) (string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -738,6 +1131,14 @@ func powCD(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
 	return roundCD(f)
 }
 
+func powEL(dcoef int64, dscale int, ecoef int64, escale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	return transcendentalEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Pow(f, d, e)
+	})
+}
+
 func log2GV(dcoef int64, dscale int) (string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -769,6 +1170,27 @@ func log2CD(dcoef int64, dscale int) (string, error) {
 	return roundCD(f)
 }
 
+func log2EL(dcoef int64, dscale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	return transcendentalEL(func(ctx el.Context, f *el.Big) *el.Big {
+		e := ctx.Log(new(el.Big), el.New(2, 0))
+		return ctx.Quo(f, ctx.Log(f, d), e)
+	})
+}
+
+func log2SS(dcoef int64, dscale int) (string, error) {
+	d := ss.New(dcoef, int32(-dscale))
+	e, err := d.Ln(100)
+	if err != nil {
+		return "", err
+	}
+	f, err := ss.New(2, 0).Ln(100)
+	if err != nil {
+		return "", err
+	}
+	return roundSS(e.DivRound(f, 100))
+}
+
 func log10GV(dcoef int64, dscale int) (string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -791,6 +1213,13 @@ func log10CD(dcoef int64, dscale int) (string, error) {
 	return roundCD(f)
 }
 
+func log10EL(dcoef int64, dscale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	return transcendentalEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Log10(f, d)
+	})
+}
+
 func logGV(dcoef int64, dscale int) (string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -813,6 +1242,13 @@ func logCD(dcoef int64, dscale int) (string, error) {
 	return roundCD(f)
 }
 
+func logEL(dcoef int64, dscale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	return transcendentalEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Log(f, d)
+	})
+}
+
 func logSS(dcoef int64, dscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e, err := d.Ln(100)
@@ -844,6 +1280,13 @@ func expCD(dcoef int64, dscale int) (string, error) {
 	return roundCD(f)
 }
 
+func expEL(dcoef int64, dscale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	return transcendentalEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Exp(f, d)
+	})
+}
+
 func expSS(dcoef int64, dscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e, err := d.ExpTaylor(100)
@@ -875,6 +1318,13 @@ func sqrtCD(dcoef int64, dscale int) (string, error) {
 	return roundCD(f)
 }
 
+func sqrtEL(dcoef int64, dscale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	return transcendentalEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Sqrt(f, d)
+	})
+}
+
 func sqrtSS(dcoef int64, dscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(5, -1)
@@ -919,6 +1369,14 @@ func quoCD(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
 	return roundCD(f)
 }
 
+func quoEL(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Quo(f, d, e)
+	})
+}
+
 func quoRemGV(dcoef int64, dscale int, ecoef int64, escale int) (string, string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -974,6 +1432,25 @@ func quoRemCD(dcoef int64, dscale int, ecoef int64, escale int) (string, string,
 	return qs, rs, nil
 }
 
+func quoRemEL(dcoef int64, dscale int, ecoef int64, escale int) (string, string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	// QuoRem returns the remainder with a wrong scale.
+	q, err := roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.QuoInt(f, d, e)
+	})
+	if err != nil {
+		return "", "", err
+	}
+	r, err := roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Rem(f, d, e)
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return q, r, nil
+}
+
 func mulSS(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(ecoef, int32(-escale))
@@ -992,6 +1469,14 @@ func mulCD(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
 	return roundCD(f)
 }
 
+func mulEL(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Mul(f, d, e)
+	})
+}
+
 func mulGV(dcoef int64, dscale int, ecoef int64, escale int) (string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -1044,6 +1529,18 @@ func prodCD(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscal
 	return roundCD(g)
 }
 
+func prodEL(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	f := el.New(fcoef, fscale)
+	return roundEL(func(ctx el.Context, g *el.Big) *el.Big {
+		guard := guardEL(ctx)
+		guard.Mul(g, d, e)
+		guard.Mul(g, g, f)
+		return ctx.Round(g)
+	})
+}
+
 func prodSS(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(ecoef, int32(-escale))
@@ -1070,6 +1567,14 @@ func addCD(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
 	return roundCD(f)
 }
 
+func addEL(dcoef int64, dscale int, ecoef int64, escale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Add(f, d, e)
+	})
+}
+
 func sumGV(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, bool) {
 	d, err := gv.New(dcoef, dscale)
 	if err != nil {
@@ -1106,6 +1611,18 @@ func sumCD(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale
 	return roundCD(g)
 }
 
+func sumEL(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	f := el.New(fcoef, fscale)
+	return roundEL(func(ctx el.Context, g *el.Big) *el.Big {
+		guard := guardEL(ctx)
+		guard.Add(g, d, e)
+		guard.Add(g, g, f)
+		return ctx.Round(g)
+	})
+}
+
 func sumSS(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(ecoef, int32(-escale))
@@ -1154,6 +1671,19 @@ func meanCD(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscal
 	return roundCD(g)
 }
 
+func meanEL(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	f := el.New(fcoef, fscale)
+	return roundEL(func(ctx el.Context, g *el.Big) *el.Big {
+		guard := guardEL(ctx)
+		guard.Add(g, d, e)
+		guard.Add(g, g, f)
+		guard.Quo(g, g, el.New(3, 0))
+		return ctx.Round(g)
+	})
+}
+
 func meanSS(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(ecoef, int32(-escale))
@@ -1215,6 +1745,15 @@ func addMulCD(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fsc
 	return roundCD(g)
 }
 
+func addMulEL(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	f := el.New(fcoef, fscale)
+	return roundEL(func(ctx el.Context, g *el.Big) *el.Big {
+		return ctx.FMA(g, e, f, d)
+	})
+}
+
 func addMulSS(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(ecoef, int32(-escale))
@@ -1259,6 +1798,18 @@ func addQuoCD(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fsc
 	return roundCD(g)
 }
 
+func addQuoEL(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	e := el.New(ecoef, escale)
+	f := el.New(fcoef, fscale)
+	return roundEL(func(ctx el.Context, g *el.Big) *el.Big {
+		guard := guardEL(ctx)
+		guard.Quo(g, e, f)
+		guard.Add(g, g, d)
+		return ctx.Round(g)
+	})
+}
+
 func addQuoSS(dcoef int64, dscale int, ecoef int64, escale int, fcoef int64, fscale int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e := ss.New(ecoef, int32(-escale))
@@ -1293,6 +1844,17 @@ func powIntCD(dcoef int64, dscale int, power int) (string, error) {
 	return roundCD(f)
 }
 
+func powIntEL(dcoef int64, dscale int, power int) (string, error) {
+	if dcoef == 0 && power == 0 {
+		return "1", nil
+	}
+	d := el.New(dcoef, dscale)
+	e := el.New(int64(power), 0)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		return ctx.Pow(f, d, e)
+	})
+}
+
 func powIntSS(dcoef int64, dscale int, power int) (string, error) {
 	d := ss.New(dcoef, int32(-dscale))
 	e, err := d.PowInt32(int32(power))
@@ -1302,6 +1864,198 @@ func powIntSS(dcoef int64, dscale int, power int) (string, error) {
 	return roundSS(e)
 }
 
+func roundHalfEvenGV(dcoef int64, dscale int, scale int) (string, bool) {
+	d, err := gv.New(dcoef, dscale)
+	if err != nil {
+		return "", false
+	}
+	if scale < 0 || scale > gv.MaxScale {
+		return "", false
+	}
+	f := d.Round(scale)
+	return f.Trim(0).String(), true
+}
+
+func roundHalfEvenCD(dcoef int64, dscale int, scale int) (string, error) {
+	d := cd.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundCD(d)
+	}
+	ctx := cd.BaseContext
+	ctx.Rounding = cd.RoundHalfEven
+	f := cd.New(0, 0)
+	_, err := ctx.Quantize(f, d, int32(-scale))
+	if err != nil {
+		return "", err
+	}
+	return roundCD(f)
+}
+
+func roundHalfEvenEL(dcoef int64, dscale int, scale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		f.Copy(d)
+		if scale >= dscale {
+			return f
+		}
+		ctx.RoundingMode = el.ToNearestEven
+		return ctx.Quantize(f, scale)
+	})
+}
+
+func roundHalfEvenSS(dcoef int64, dscale int, scale int) (string, error) {
+	d := ss.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundSS(d)
+	}
+	f := d.RoundBank(int32(scale))
+	return roundSS(f)
+}
+
+func truncGV(dcoef int64, dscale int, scale int) (string, bool) {
+	d, err := gv.New(dcoef, dscale)
+	if err != nil {
+		return "", false
+	}
+	if scale < 0 || scale > gv.MaxScale {
+		return "", false
+	}
+	f := d.Trunc(scale)
+	return f.Trim(0).String(), true
+}
+
+func truncCD(dcoef int64, dscale int, scale int) (string, error) {
+	d := cd.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundCD(d)
+	}
+	ctx := cd.BaseContext
+	ctx.Rounding = cd.RoundDown
+	f := cd.New(0, 0)
+	_, err := ctx.Quantize(f, d, int32(-scale))
+	if err != nil {
+		return "", err
+	}
+	return roundCD(f)
+}
+
+func truncEL(dcoef int64, dscale int, scale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		f.Copy(d)
+		if scale >= dscale {
+			return f
+		}
+		ctx.RoundingMode = el.ToZero
+		return ctx.Quantize(f, scale)
+	})
+}
+
+func truncSS(dcoef int64, dscale int, scale int) (string, error) {
+	d := ss.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundSS(d)
+	}
+	f := d.Truncate(int32(scale))
+	return roundSS(f)
+}
+
+func ceilGV(dcoef int64, dscale int, scale int) (string, bool) {
+	d, err := gv.New(dcoef, dscale)
+	if err != nil {
+		return "", false
+	}
+	if scale < 0 || scale > gv.MaxScale {
+		return "", false
+	}
+	f := d.Ceil(scale)
+	return f.Trim(0).String(), true
+}
+
+func ceilCD(dcoef int64, dscale int, scale int) (string, error) {
+	d := cd.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundCD(d)
+	}
+	ctx := cd.BaseContext
+	ctx.Rounding = cd.RoundCeiling
+	f := cd.New(0, 0)
+	_, err := ctx.Quantize(f, d, int32(-scale))
+	if err != nil {
+		return "", err
+	}
+	return roundCD(f)
+}
+
+func ceilEL(dcoef int64, dscale int, scale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		f.Copy(d)
+		if scale >= dscale {
+			return f
+		}
+		ctx.RoundingMode = el.ToPositiveInf
+		return ctx.Quantize(f, scale)
+	})
+}
+
+func ceilSS(dcoef int64, dscale int, scale int) (string, error) {
+	d := ss.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundSS(d)
+	}
+	f := d.RoundCeil(int32(scale))
+	return roundSS(f)
+}
+
+func floorGV(dcoef int64, dscale int, scale int) (string, bool) {
+	d, err := gv.New(dcoef, dscale)
+	if err != nil {
+		return "", false
+	}
+	if scale < 0 || scale > gv.MaxScale {
+		return "", false
+	}
+	f := d.Floor(scale)
+	return f.Trim(0).String(), true
+}
+
+func floorCD(dcoef int64, dscale int, scale int) (string, error) {
+	d := cd.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundCD(d)
+	}
+	ctx := cd.BaseContext
+	ctx.Rounding = cd.RoundFloor
+	f := cd.New(0, 0)
+	_, err := ctx.Quantize(f, d, int32(-scale))
+	if err != nil {
+		return "", err
+	}
+	return roundCD(f)
+}
+
+func floorEL(dcoef int64, dscale int, scale int) (string, error) {
+	d := el.New(dcoef, dscale)
+	return roundEL(func(ctx el.Context, f *el.Big) *el.Big {
+		f.Copy(d)
+		if scale >= dscale {
+			return f
+		}
+		ctx.RoundingMode = el.ToNegativeInf
+		return ctx.Quantize(f, scale)
+	})
+}
+
+func floorSS(dcoef int64, dscale int, scale int) (string, error) {
+	d := ss.New(dcoef, int32(-dscale))
+	if scale >= dscale {
+		return roundSS(d)
+	}
+	f := d.RoundFloor(int32(scale))
+	return roundSS(f)
+}
+
 func roundSS(d ss.Decimal) (string, error) {
 	// Check if number fits uint64 coefficient
 	prec := int32(d.NumDigits())
@@ -1365,3 +2119,101 @@ func roundCD(d *cd.Decimal) (string, error) {
 	}
 	return d.Text('f'), nil
 }
+
+// is32bit reports whether int is 32 bits wide.
+// On such platforms ericlagergren/decimal returns NaN for most operations,
+// so it is not used as a reference.
+const is32bit = math.MaxInt == math.MaxInt32
+
+// ctxEL is a context that models the precision of govalues.
+var ctxEL = el.Context{
+	Precision:    gv.MaxPrec,
+	RoundingMode: el.ToNearestEven,
+}
+
+// guardEL returns a context with extra digits for functions
+// that are not correctly rounded in a 19-digit context.
+func guardEL(ctx el.Context) el.Context {
+	ctx.Precision += 40
+	return ctx
+}
+
+// transcendentalEL evaluates fn in the guard context and rounds the result
+// once, and also evaluates fn in the 19-digit context itself, where
+// ericlagergren/decimal may be off by one unit in the last place.
+func transcendentalEL(fn func(ctx el.Context, d *el.Big) *el.Big) (string, string, error) {
+	want, err := roundEL(func(ctx el.Context, d *el.Big) *el.Big {
+		return ctx.Round(fn(guardEL(ctx), d))
+	})
+	if err != nil {
+		return "", "", err
+	}
+	got, err := roundEL(fn)
+	if err != nil {
+		return "", "", err
+	}
+	return want, got, nil
+}
+
+// withinULP reports whether got and want differ by at most one unit
+// in the last place of got padded to 19 digits.
+func withinULP(got, want string) bool {
+	d, err := gv.Parse(got)
+	if err != nil {
+		return false
+	}
+	e, err := gv.Parse(want)
+	if err != nil {
+		return false
+	}
+	diff, err := d.Sub(e)
+	if err != nil {
+		return false
+	}
+	ulp, err := gv.New(1, d.Pad(gv.MaxScale).Scale())
+	if err != nil {
+		return false
+	}
+	return diff.Abs().Cmp(ulp) <= 0
+}
+
+// roundEL evaluates fn in a 19-digit context and rounds the result to
+// at most 19 digits after the decimal point.
+// If the result has leading zeros after the decimal point, fn is evaluated
+// again with fewer significant digits, so that the result is rounded only once.
+func roundEL(fn func(ctx el.Context, d *el.Big) *el.Big) (string, error) {
+	d := fn(ctxEL, new(el.Big))
+	if !d.IsFinite() {
+		return "", fmt.Errorf("%v", d.Context.Conditions)
+	}
+	// Leading zeros
+	prec := d.Precision()
+	scale := d.Scale()
+	if d.Sign() != 0 && scale > gv.MaxScale {
+		if digits := gv.MaxScale - scale + prec; digits > 0 && digits < ctxEL.Precision {
+			ctx := ctxEL
+			ctx.Precision = digits
+			d = fn(ctx, new(el.Big))
+			if !d.IsFinite() {
+				return "", fmt.Errorf("%v", d.Context.Conditions)
+			}
+		}
+	}
+	// Check if number fits uint64 coefficient
+	prec = d.Precision()
+	scale = d.Scale()
+	if d.Sign() != 0 && prec-scale > gv.MaxPrec {
+		return "", fmt.Errorf("overflow (prec=%v, scale=%v)", prec, scale)
+	}
+	// Rounding
+	if scale > gv.MaxScale {
+		ctxEL.Quantize(d, gv.MaxScale)
+	}
+	// Trailing Zeros
+	ctxEL.Reduce(d)
+	// Negative Zeros
+	if d.Sign() == 0 {
+		d.SetSignbit(false)
+	}
+	return fmt.Sprintf("%f", d), nil
+}
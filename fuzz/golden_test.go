@@ -0,0 +1,384 @@
+package decimal_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	gv "github.com/govalues/decimal"
+	"github.com/govalues/decimal-tests/internal/corpus"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+// TestGolden evaluates the govalues helpers over the seed corpus and compares
+// the results with testdata/golden.txt.
+// The golden file is produced by a 64-bit run:
+//
+//	go test -run ^TestGolden$ -update
+//
+// and is checked on every platform, including GOARCH=386, where int is 32 bits.
+func TestGolden(t *testing.T) {
+	got := golden()
+	name := filepath.Join("testdata", "golden.txt")
+	if *update {
+		err := os.WriteFile(name, got, 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile(%q) failed: %v", name, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) failed: %v", name, err)
+	}
+	gotLines := strings.Split(string(got), "\n")
+	wantLines := strings.Split(string(want), "\n")
+	if len(gotLines) != len(wantLines) {
+		t.Errorf("golden() returned %v lines, want %v", len(gotLines), len(wantLines))
+	}
+	for i := range min(len(gotLines), len(wantLines)) {
+		if gotLines[i] != wantLines[i] {
+			t.Errorf("golden() line %v = %q, want %q", i+1, gotLines[i], wantLines[i])
+		}
+	}
+}
+
+// TestGoldenHash evaluates every govalues operation over a large set of
+// pseudo-random vectors and compares the SHA-256 hash of the results
+// with testdata/golden.sha256.
+// Any difference between platforms or compiler settings, such as GOARCH=386
+// or GOAMD64=v3, changes the hash.
+// The golden hash is produced by a 64-bit run:
+//
+//	go test -run ^TestGoldenHash$ -update
+func TestGoldenHash(t *testing.T) {
+	h := sha256.New()
+	goldenVectors(h, 20000)
+	got := hex.EncodeToString(h.Sum(nil))
+	name := filepath.Join("testdata", "golden.sha256")
+	if *update {
+		err := os.WriteFile(name, []byte(got+"\n"), 0o600)
+		if err != nil {
+			t.Fatalf("WriteFile(%q) failed: %v", name, err)
+		}
+		return
+	}
+	want, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("ReadFile(%q) failed: %v", name, err)
+	}
+	if got != strings.TrimSpace(string(want)) {
+		t.Errorf("goldenVectors() hash = %v, want %v", got, strings.TrimSpace(string(want)))
+	}
+}
+
+// goldenVectors writes the results of all operations over n vectors to w.
+// The vectors are generated with a fixed seed, so that they are the same on
+// every platform.
+// Results are written with their scale and error, and are marshalled,
+// so that a difference in any of them changes the output.
+func goldenVectors(w io.Writer, n int) {
+	random := rand.New(rand.NewPCG(1, 2))
+	next := func() gv.Decimal {
+		// Coefficients with 1 to 19 digits and all scales
+		coef := random.Int64() / pow10[random.IntN(len(pow10))]
+		if random.IntN(2) == 0 {
+			coef = -coef
+		}
+		return gv.MustNew(coef, random.IntN(gv.MaxScale+1))
+	}
+	put := func(name string, r gv.Decimal, err error) {
+		fmt.Fprintf(w, "%v = %v %v\n", name, r.String(), err)
+		if err == nil {
+			for _, line := range marshalGV(r) {
+				fmt.Fprintln(w, line)
+			}
+		}
+	}
+	val := func(name string, v any) {
+		fmt.Fprintf(w, "%v = %v\n", name, v)
+	}
+	for range n {
+		d, e, f := next(), next(), next()
+		scale := random.IntN(gv.MaxScale + 1)
+		power := random.IntN(21) - 10
+
+		// Inputs
+		for _, line := range encodeGV(d) {
+			fmt.Fprintln(w, line)
+		}
+		for _, line := range unmarshalGV(d) {
+			fmt.Fprintln(w, line)
+		}
+		for _, line := range marshalGV(e) {
+			fmt.Fprintln(w, line)
+		}
+		for _, line := range marshalGV(f) {
+			fmt.Fprintln(w, line)
+		}
+
+		// Unary operations
+		r, err := d.Sqrt()
+		put("sqrt", r, err)
+		r, err = d.Exp()
+		put("exp", r, err)
+		r, err = d.Log()
+		put("log", r, err)
+		r, err = d.Log2()
+		put("log2", r, err)
+		r, err = d.Log10()
+		put("log10", r, err)
+		r, err = d.Inv()
+		put("inv", r, err)
+		put("neg", d.Neg(), nil)
+		put("abs", d.Abs(), nil)
+		put("one", d.One(), nil)
+		put("zero", d.Zero(), nil)
+		put("ulp", d.ULP(), nil)
+		val("sign", d.Sign())
+		val("prec", d.Prec())
+		val("scale", d.Scale())
+		val("minScale", d.MinScale())
+		val("coef", d.Coef())
+		val("isInt", d.IsInt())
+		val("isNeg", d.IsNeg())
+		val("isPos", d.IsPos())
+		val("isZero", d.IsZero())
+		val("isOne", d.IsOne())
+		val("withinOne", d.WithinOne())
+
+		// Operations with a scale or a power
+		put("round", d.Round(scale), nil)
+		put("trunc", d.Trunc(scale), nil)
+		put("ceil", d.Ceil(scale), nil)
+		put("floor", d.Floor(scale), nil)
+		put("trim", d.Trim(scale), nil)
+		put("pad", d.Pad(scale), nil)
+		put("rescale", d.Rescale(scale), nil)
+		r, err = d.PowInt(power)
+		put("powInt", r, err)
+
+		// Binary operations
+		r, err = d.Add(e)
+		put("add", r, err)
+		r, err = d.Sub(e)
+		put("sub", r, err)
+		r, err = d.SubAbs(e)
+		put("subAbs", r, err)
+		r, err = d.Mul(e)
+		put("mul", r, err)
+		r, err = d.Quo(e)
+		put("quo", r, err)
+		r, err = d.Pow(e)
+		put("pow", r, err)
+		q, rem, err := d.QuoRem(e)
+		put("quoRem.q", q, err)
+		put("quoRem.r", rem, err)
+		r, err = d.AddExact(e, scale)
+		put("addExact", r, err)
+		r, err = d.SubExact(e, scale)
+		put("subExact", r, err)
+		r, err = d.MulExact(e, scale)
+		put("mulExact", r, err)
+		r, err = d.QuoExact(e, scale)
+		put("quoExact", r, err)
+		put("quantize", d.Quantize(e), nil)
+		put("copySign", d.CopySign(e), nil)
+		put("min", d.Min(e), nil)
+		put("max", d.Max(e), nil)
+		val("cmp", d.Cmp(e))
+		val("cmpAbs", d.CmpAbs(e))
+		val("cmpTotal", d.CmpTotal(e))
+		val("equal", d.Equal(e))
+		val("less", d.Less(e))
+		val("sameScale", d.SameScale(e))
+
+		// Ternary operations
+		r, err = d.AddMul(e, f)
+		put("addMul", r, err)
+		r, err = d.SubMul(e, f)
+		put("subMul", r, err)
+		r, err = d.AddQuo(e, f)
+		put("addQuo", r, err)
+		r, err = d.SubQuo(e, f)
+		put("subQuo", r, err)
+		r, err = d.AddMulExact(e, f, scale)
+		put("addMulExact", r, err)
+		r, err = d.SubMulExact(e, f, scale)
+		put("subMulExact", r, err)
+		r, err = d.AddQuoExact(e, f, scale)
+		put("addQuoExact", r, err)
+		r, err = d.SubQuoExact(e, f, scale)
+		put("subQuoExact", r, err)
+		r, err = d.Clamp(e, f)
+		put("clamp", r, err)
+		r, err = gv.Sum(d, e, f)
+		put("sum", r, err)
+		r, err = gv.Prod(d, e, f)
+		put("prod", r, err)
+		r, err = gv.Mean(d, e, f)
+		put("mean", r, err)
+	}
+}
+
+var pow10 = [...]int64{
+	1, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9,
+	1e10, 1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18,
+}
+
+// golden returns a line for every helper and argument combination.
+// Ternary operations are left out to keep the file small.
+func golden() []byte {
+	var buf bytes.Buffer
+	res := func(s string, ok bool) string {
+		if !ok {
+			return "failed"
+		}
+		return s
+	}
+
+	// Unary operations
+	for _, d := range corpus.Decimals {
+		for _, op := range []struct {
+			name string
+			fn   func(int64, int) (string, bool)
+		}{
+			{"sqrt", sqrtGV},
+			{"exp", expGV},
+			{"log", logGV},
+			{"log2", log2GV},
+			{"log10", log10GV},
+		} {
+			s, ok := op.fn(d.Coef, d.Scale)
+			fmt.Fprintf(&buf, "%v(%v, %v) = %v\n", op.name, d.Coef, d.Scale, res(s, ok))
+		}
+	}
+
+	// Operations with a scale or a power
+	for _, d := range corpus.Decimals {
+		for _, op := range []struct {
+			name string
+			fn   func(int64, int, int) (string, bool)
+		}{
+			{"roundHalfEven", roundHalfEvenGV},
+			{"trunc", truncGV},
+			{"ceil", ceilGV},
+			{"floor", floorGV},
+		} {
+			for s := 0; s <= gv.MaxScale; s++ {
+				r, ok := op.fn(d.Coef, d.Scale, s)
+				fmt.Fprintf(&buf, "%v(%v, %v, %v) = %v\n", op.name, d.Coef, d.Scale, s, res(r, ok))
+			}
+		}
+		for p := -10; p <= 10; p++ {
+			r, ok := powIntGV(d.Coef, d.Scale, p)
+			fmt.Fprintf(&buf, "powInt(%v, %v, %v) = %v\n", d.Coef, d.Scale, p, res(r, ok))
+		}
+	}
+
+	// Binary operations
+	for _, d := range corpus.Decimals {
+		for _, e := range corpus.Decimals {
+			for _, op := range []struct {
+				name string
+				fn   func(int64, int, int64, int) (string, bool)
+			}{
+				{"add", addGV},
+				{"mul", mulGV},
+				{"quo", quoGV},
+				{"pow", powGV},
+			} {
+				s, ok := op.fn(d.Coef, d.Scale, e.Coef, e.Scale)
+				fmt.Fprintf(&buf, "%v(%v, %v, %v, %v) = %v\n", op.name, d.Coef, d.Scale, e.Coef, e.Scale, res(s, ok))
+			}
+			q, r, ok := quoRemGV(d.Coef, d.Scale, e.Coef, e.Scale)
+			fmt.Fprintf(&buf, "quoRem(%v, %v, %v, %v) = %v\n", d.Coef, d.Scale, e.Coef, e.Scale, res(q+" "+r, ok))
+		}
+	}
+
+	// Serialization
+	for _, d := range corpus.Decimals {
+		for _, line := range encodeGV(gv.MustNew(d.Coef, d.Scale)) {
+			fmt.Fprintf(&buf, "encode(%v, %v) %v\n", d.Coef, d.Scale, line)
+		}
+	}
+	return buf.Bytes()
+}
+
+// encodeGV returns the results of all conversions and encodings of a decimal.
+func encodeGV(d gv.Decimal) []string {
+	lines := marshalGV(d)
+	p, err := gv.Parse(d.String())
+	lines = append(lines, fmt.Sprintf("parse = %v %v", p, err))
+	f, ok := d.Float64()
+	lines = append(lines, fmt.Sprintf("float64 = %v %v", f, ok))
+	n, err := gv.NewFromFloat64(f)
+	lines = append(lines, fmt.Sprintf("newFromFloat64 = %v %v", n, err))
+	for s := 0; s <= gv.MaxScale; s++ {
+		whole, frac, ok := d.Int64(s)
+		lines = append(lines, fmt.Sprintf("int64(%v) = %v %v %v", s, whole, frac, ok))
+		if ok {
+			n, err := gv.NewFromInt64(whole, frac, s)
+			lines = append(lines, fmt.Sprintf("newFromInt64(%v) = %v %v", s, n, err))
+		}
+	}
+	return lines
+}
+
+// marshalGV returns the text, JSON, binary, and BSON encodings of a decimal.
+func marshalGV(d gv.Decimal) []string {
+	var lines []string
+	text, err := d.MarshalText()
+	lines = append(lines, fmt.Sprintf("text = %s %v", text, err))
+	json, err := d.MarshalJSON()
+	lines = append(lines, fmt.Sprintf("json = %s %v", json, err))
+	bin, err := d.MarshalBinary()
+	lines = append(lines, fmt.Sprintf("binary = %v %v", hex.EncodeToString(bin), err))
+	typ, bson, err := d.MarshalBSONValue()
+	lines = append(lines, fmt.Sprintf("bson = %v %v %v", typ, hex.EncodeToString(bson), err))
+	return lines
+}
+
+// unmarshalGV returns the results of formatting a decimal and of decoding
+// its encodings, parsing it with every scale, and scanning and valuing it.
+func unmarshalGV(d gv.Decimal) []string {
+	var lines []string
+	for _, verb := range []string{"%v", "%s", "%q", "%f", "%.0f", "%.2f", "%.19f", "%k", "%+.3f", "%-25.4f|"} {
+		lines = append(lines, fmt.Sprintf("format(%v) = %v", verb, fmt.Sprintf(verb, d)))
+	}
+	text, _ := d.MarshalText()
+	var u gv.Decimal
+	err := u.UnmarshalText(text)
+	lines = append(lines, fmt.Sprintf("unmarshalText = %v %v", u, err))
+	json, _ := d.MarshalJSON()
+	u = gv.Decimal{}
+	err = u.UnmarshalJSON(json)
+	lines = append(lines, fmt.Sprintf("unmarshalJSON = %v %v", u, err))
+	bin, _ := d.MarshalBinary()
+	u = gv.Decimal{}
+	err = u.UnmarshalBinary(bin)
+	lines = append(lines, fmt.Sprintf("unmarshalBinary = %v %v", u, err))
+	typ, bson, _ := d.MarshalBSONValue()
+	u = gv.Decimal{}
+	err = u.UnmarshalBSONValue(typ, bson)
+	lines = append(lines, fmt.Sprintf("unmarshalBSONValue = %v %v", u, err))
+	v, err := d.Value()
+	lines = append(lines, fmt.Sprintf("value = %v %v", v, err))
+	u = gv.Decimal{}
+	err = u.Scan(v)
+	lines = append(lines, fmt.Sprintf("scan = %v %v", u, err))
+	for s := 0; s <= gv.MaxScale; s++ {
+		p, err := gv.ParseExact(d.String(), s)
+		lines = append(lines, fmt.Sprintf("parseExact(%v) = %v %v", s, p, err))
+	}
+	return lines
+}
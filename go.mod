@@ -4,6 +4,7 @@ go 1.23
 
 require (
 	github.com/cockroachdb/apd/v3 v3.2.1
+	github.com/ericlagergren/decimal v0.0.0-20240411145413-00de7ca16731
 	github.com/go-sql-driver/mysql v1.8.1
 	github.com/govalues/decimal v0.1.35
 	github.com/jackc/pgx/v5 v5.7.2
@@ -0,0 +1,58 @@
+// Package corpus holds the seed decimals shared by the fuzz tests
+// and the compat module.
+package corpus
+
+import "math"
+
+// Decimals are the seed decimals: the largest and smallest coefficients,
+// small integers, values around one and the powers of ten, and zeros,
+// with both signs.
+var Decimals = []struct {
+	Scale int
+	Coef  int64
+}{
+	{0, math.MaxInt64},
+	{1, math.MaxInt64},
+	{2, math.MaxInt64},
+	{0, 5000000000000000000},
+	{0, 1000000000000000000},
+	{19, math.MaxInt64},
+	{0, 9},
+	{0, 7},
+	{0, 6},
+	{0, 3},
+	{0, 2},
+	{0, 1},
+	{18, 3000000000000000003},
+	{18, 3000000000000000000},
+	{18, 2000000000000000002},
+	{18, 2000000000000000000},
+	{18, 1000000000000000001},
+	{18, 1000000000000000000},
+	{19, 3},
+	{19, 2},
+	{19, 1},
+	{0, 0},
+	{19, 0},
+	{0, math.MinInt64},
+	{1, math.MinInt64},
+	{2, math.MinInt64},
+	{0, -5000000000000000000},
+	{0, -1000000000000000000},
+	{19, math.MinInt64},
+	{0, -9},
+	{0, -7},
+	{0, -6},
+	{0, -3},
+	{0, -2},
+	{0, -1},
+	{18, -3000000000000000003},
+	{18, -3000000000000000000},
+	{18, -2000000000000000002},
+	{18, -2000000000000000000},
+	{18, -1000000000000000001},
+	{18, -1000000000000000000},
+	{19, -3},
+	{19, -2},
+	{19, -1},
+}